@@ -0,0 +1,349 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/bsr"
+	"github.com/uzdada/protodiff/internal/adapters/grpc"
+	"github.com/uzdada/protodiff/internal/config"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+	"github.com/uzdada/protodiff/internal/scanner"
+)
+
+// Exit codes of the check command
+const (
+	exitPassed = 0
+	// exitFailed means more results failed than the threshold allows
+	exitFailed = 1
+	// exitError means the check could not run, e.g. invalid flags or an unreachable cluster
+	exitError = 2
+)
+
+// Values of the -fail-on flag
+const (
+	failOnMismatch = "mismatch"
+	failOnUnknown  = "unknown"
+)
+
+const checkUsage = `Usage: protodiff check [flags]
+
+Validates gRPC schemas once, prints the results and exits non-zero when more
+results fail than -max-failures allows, or when no result could be validated
+(every result is UNKNOWN), whatever -fail-on says. Without -endpoint, the pods of the
+configured clusters are scanned, using the same environment variables as the
+daemon. With -endpoint, the given gRPC servers are validated directly.
+
+Exit codes: 0 passed, 1 too many failures or nothing validated, 2 the check
+could not run.
+
+Flags:
+`
+
+// endpointFlags collects repeated -endpoint ADDRESS=MODULE flags
+type endpointFlags []scanner.Endpoint
+
+func (f *endpointFlags) String() string {
+	var endpoints []string
+	for _, endpoint := range *f {
+		endpoints = append(endpoints, endpoint.Address+"="+endpoint.Module)
+	}
+	return strings.Join(endpoints, ",")
+}
+
+func (f *endpointFlags) Set(value string) error {
+	address, module, ok := strings.Cut(value, "=")
+	if !ok || module == "" {
+		return fmt.Errorf("expected ADDRESS=MODULE, e.g. localhost:9090=buf.build/acme/user")
+	}
+	if _, port, err := net.SplitHostPort(address); err != nil || port == "" {
+		return fmt.Errorf("invalid address %q (expected host:port)", address)
+	}
+	*f = append(*f, scanner.Endpoint{Address: address, Module: module})
+	return nil
+}
+
+// checkOptions are the parsed flags of the check command
+type checkOptions struct {
+	request     domain.ScanRequest
+	kubeconfig  string
+	endpoints   endpointFlags
+	failOn      string
+	maxFailures string
+	output      string
+	timeout     time.Duration
+	verbose     bool
+}
+
+// checkSummary counts the results of a check
+type checkSummary struct {
+	Total    int `json:"total"`
+	Sync     int `json:"sync"`
+	Mismatch int `json:"mismatch"`
+	Unknown  int `json:"unknown"`
+	// Failures are the results with a status selected by -fail-on
+	Failures int `json:"failures"`
+	// AllowedFailures is the threshold from -max-failures
+	AllowedFailures int  `json:"allowed_failures"`
+	Passed          bool `json:"passed"`
+}
+
+// runCheck runs the check command and returns its exit code
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	opts, err := parseCheckFlags(args, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitPassed
+		}
+		return exitError
+	}
+	if !opts.verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	results, err := runChecks(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(stderr, "Error: no pods matched")
+		return exitError
+	}
+
+	summary := summarizeChecks(results, opts.failOn, opts.maxFailures)
+	if opts.output == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(struct {
+			Results []*domain.ScanResult `json:"results"`
+			Summary checkSummary         `json:"summary"`
+		}{results, summary})
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to encode results: %v\n", err)
+			return exitError
+		}
+	} else {
+		printCheckResults(stdout, results, summary)
+	}
+
+	if !summary.Passed {
+		return exitFailed
+	}
+	return exitPassed
+}
+
+// parseCheckFlags parses and validates the flags of the check command
+func parseCheckFlags(args []string, stderr io.Writer) (checkOptions, error) {
+	var opts checkOptions
+	var tlsOpts scanner.Endpoint
+
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, checkUsage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to a kubeconfig file, overriding $KUBECONFIG")
+	flags.StringVar(&opts.request.ClusterName, "cluster", "", "Only scan this cluster")
+	flags.StringVar(&opts.request.Namespace, "namespace", "", "Only scan pods in this namespace")
+	flags.StringVar(&opts.request.ServiceName, "service", "", "Only scan pods of this service")
+	flags.StringVar(&opts.request.PodName, "pod", "", "Only scan this pod")
+	flags.Var(&opts.endpoints, "endpoint", "Validate the gRPC server at ADDRESS against the BSR MODULE instead of scanning clusters, as ADDRESS=MODULE (repeatable)")
+	flags.BoolVar(&tlsOpts.TLS, "tls", false, "Connect to endpoints with TLS")
+	flags.BoolVar(&tlsOpts.InsecureSkipVerify, "insecure-skip-verify", false, "Skip verifying the certificates of endpoints")
+	flags.StringVar(&tlsOpts.ServerName, "server-name", "", "Expected server name in the certificates of endpoints")
+	flags.BoolVar(&tlsOpts.Strict, "strict", false, "Treat missing and extra services of endpoints as drift")
+	flags.StringVar(&opts.failOn, "fail-on", failOnMismatch, "Statuses counted as failures: mismatch, or unknown for MISMATCH and UNKNOWN. The check fails anyway when every result is UNKNOWN")
+	flags.StringVar(&opts.maxFailures, "max-failures", "0", "Failures tolerated before exiting non-zero, as a count or a percentage of the results (e.g. 10%)")
+	flags.StringVar(&opts.output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&opts.timeout, "timeout", 5*time.Minute, "Time limit for the whole check")
+	flags.BoolVar(&opts.verbose, "v", false, "Log scan progress to stderr")
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return opts, fmt.Errorf("unexpected arguments")
+	}
+
+	var problems []string
+	if opts.failOn != failOnMismatch && opts.failOn != failOnUnknown {
+		problems = append(problems, fmt.Sprintf("invalid -fail-on %q (expected mismatch or unknown)", opts.failOn))
+	}
+	if _, err := allowedFailures(opts.maxFailures, 0); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if opts.output != "text" && opts.output != "json" {
+		problems = append(problems, fmt.Sprintf("invalid -output %q (expected text or json)", opts.output))
+	}
+	if len(opts.endpoints) > 0 && opts.request != (domain.ScanRequest{}) {
+		problems = append(problems, "-endpoint can't be combined with -cluster, -namespace, -service or -pod")
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Fprintln(stderr, problem)
+		}
+		return opts, fmt.Errorf("invalid flags")
+	}
+
+	for i := range opts.endpoints {
+		opts.endpoints[i].TLS = tlsOpts.TLS
+		opts.endpoints[i].InsecureSkipVerify = tlsOpts.InsecureSkipVerify
+		opts.endpoints[i].ServerName = tlsOpts.ServerName
+		opts.endpoints[i].Strict = tlsOpts.Strict
+	}
+	return opts, nil
+}
+
+// runChecks validates the endpoints, or else scans the configured clusters once
+func runChecks(ctx context.Context, opts checkOptions) ([]*domain.ScanResult, error) {
+	cfg := config.Load()
+	cfg.Kubeconfig = opts.kubeconfig
+	// A check must not announce drift through Events; the daemon does that
+	cfg.RecordEvents = false
+
+	grpcClient := grpc.NewReflectionClient()
+	bsrClient := bsr.NewBufClient()
+	dataStore := store.NewMemory(store.Options{})
+
+	if len(opts.endpoints) > 0 {
+		scannerInstance := scanner.NewScanner(nil, grpcClient, bsrClient, dataStore, cfg)
+		var results []*domain.ScanResult
+		for _, endpoint := range opts.endpoints {
+			results = append(results, scannerInstance.CheckEndpoint(ctx, endpoint))
+		}
+		return results, nil
+	}
+
+	clusters, err := newClusters(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, cluster := range clusters {
+			cluster.Close()
+		}
+	}()
+
+	scannerInstance := scanner.NewScanner(clusters, grpcClient, bsrClient, dataStore, cfg)
+	results, err := scannerInstance.Check(ctx, opts.request)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.ClusterName != b.ClusterName {
+			return a.ClusterName < b.ClusterName
+		}
+		if a.PodNamespace != b.PodNamespace {
+			return a.PodNamespace < b.PodNamespace
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.KubeService < b.KubeService
+	})
+	return results, nil
+}
+
+// summarizeChecks counts the results and applies the failure threshold
+func summarizeChecks(results []*domain.ScanResult, failOn, maxFailures string) checkSummary {
+	summary := checkSummary{Total: len(results)}
+	for _, result := range results {
+		switch result.Status {
+		case domain.StatusSync:
+			summary.Sync++
+		case domain.StatusMismatch:
+			summary.Mismatch++
+			summary.Failures++
+		default:
+			summary.Unknown++
+			if failOn == failOnUnknown {
+				summary.Failures++
+			}
+		}
+	}
+	summary.AllowedFailures, _ = allowedFailures(maxFailures, summary.Total)
+	// A check that validated nothing proves nothing, even if UNKNOWN is tolerated
+	summary.Passed = summary.Failures <= summary.AllowedFailures && summary.Unknown < summary.Total
+	return summary
+}
+
+// allowedFailures parses a -max-failures threshold, a count or a percentage of total
+func allowedFailures(threshold string, total int) (int, error) {
+	if percent, ok := strings.CutSuffix(threshold, "%"); ok {
+		value, err := strconv.ParseFloat(percent, 64)
+		if err != nil || value < 0 || value > 100 {
+			return 0, fmt.Errorf("invalid -max-failures %q (expected a percentage between 0%% and 100%%)", threshold)
+		}
+		return int(math.Floor(value / 100 * float64(total))), nil
+	}
+	count, err := strconv.Atoi(threshold)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("invalid -max-failures %q (expected a count or a percentage)", threshold)
+	}
+	return count, nil
+}
+
+// printCheckResults prints the results as a table followed by the summary
+func printCheckResults(w io.Writer, results []*domain.ScanResult, summary checkSummary) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STATUS\tCLUSTER\tNAMESPACE\tPOD\tSERVICE\tMODULE\tMESSAGE")
+	for _, result := range results {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", result.Status,
+			orDash(result.ClusterName), orDash(result.PodNamespace), result.PodName,
+			result.ServiceName, orDash(result.BSRModule), result.Message)
+	}
+	table.Flush()
+
+	verdict := "PASSED"
+	if !summary.Passed {
+		verdict = "FAILED"
+	}
+	fmt.Fprintf(w, "\n%d checked: %d SYNC, %d MISMATCH, %d UNKNOWN. %s: %d failure(s), %d allowed.\n",
+		summary.Total, summary.Sync, summary.Mismatch, summary.Unknown, verdict, summary.Failures, summary.AllowedFailures)
+	if summary.Unknown == summary.Total {
+		fmt.Fprintln(w, "No result could be validated.")
+	}
+}
+
+// orDash returns value, or "-" when it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+func TestAllowedFailures(t *testing.T) {
+	tests := []struct {
+		threshold string
+		total     int
+		want      int
+		wantErr   bool
+	}{
+		{"0", 10, 0, false},
+		{"3", 10, 3, false},
+		{"3", 0, 3, false},
+		{"10%", 10, 1, false},
+		{"10%", 9, 0, false},
+		{"25%", 10, 2, false},
+		{"12.5%", 16, 2, false},
+		{"0%", 10, 0, false},
+		{"100%", 7, 7, false},
+		{"-1", 10, 0, true},
+		{"ten", 10, 0, true},
+		{"", 10, 0, true},
+		{"101%", 10, 0, true},
+		{"-5%", 10, 0, true},
+		{"%", 10, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := allowedFailures(tt.threshold, tt.total)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("allowedFailures(%q, %d) error = %v, wantErr %v", tt.threshold, tt.total, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("allowedFailures(%q, %d) = %d, want %d", tt.threshold, tt.total, got, tt.want)
+		}
+	}
+}
+
+// checkResults builds results with the given statuses
+func checkResults(statuses ...domain.DiffStatus) []*domain.ScanResult {
+	var results []*domain.ScanResult
+	for _, status := range statuses {
+		results = append(results, &domain.ScanResult{Status: status})
+	}
+	return results
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	sync, mismatch, unknown := domain.StatusSync, domain.StatusMismatch, domain.StatusUnknown
+	tests := []struct {
+		name         string
+		results      []*domain.ScanResult
+		failOn       string
+		maxFailures  string
+		wantFailures int
+		wantAllowed  int
+		wantPassed   bool
+	}{
+		{"all in sync", checkResults(sync, sync), failOnMismatch, "0", 0, 0, true},
+		{"one mismatch", checkResults(sync, mismatch), failOnMismatch, "0", 1, 0, false},
+		{"mismatch within count", checkResults(sync, mismatch), failOnMismatch, "1", 1, 1, true},
+		{"mismatch above count", checkResults(mismatch, mismatch, sync), failOnMismatch, "1", 2, 1, false},
+		{"mismatch within percentage", checkResults(mismatch, sync, sync, sync), failOnMismatch, "25%", 1, 1, true},
+		{"mismatch above percentage", checkResults(mismatch, sync, sync), failOnMismatch, "25%", 1, 0, false},
+		{"unknown tolerated by default", checkResults(sync, unknown), failOnMismatch, "0", 0, 0, true},
+		{"unknown failing", checkResults(sync, unknown), failOnUnknown, "0", 1, 0, false},
+		{"unknown and mismatch failing", checkResults(mismatch, unknown, sync, sync), failOnUnknown, "50%", 2, 2, true},
+		{"nothing validated", checkResults(unknown, unknown), failOnMismatch, "0", 0, 0, false},
+		{"nothing validated within threshold", checkResults(unknown), failOnUnknown, "100%", 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizeChecks(tt.results, tt.failOn, tt.maxFailures)
+			if summary.Failures != tt.wantFailures || summary.AllowedFailures != tt.wantAllowed || summary.Passed != tt.wantPassed {
+				t.Errorf("summarizeChecks() = %d failures, %d allowed, passed %v, want %d, %d, %v",
+					summary.Failures, summary.AllowedFailures, summary.Passed, tt.wantFailures, tt.wantAllowed, tt.wantPassed)
+			}
+			if summary.Total != len(tt.results) || summary.Sync+summary.Mismatch+summary.Unknown != summary.Total {
+				t.Errorf("summarizeChecks() counts %+v don't add up to %d results", summary, len(tt.results))
+			}
+		})
+	}
+}
+
+func TestParseCheckFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"defaults", nil, false},
+		{"filters", []string{"-cluster", "prod", "-namespace", "users", "-service", "users", "-pod", "users-0"}, false},
+		{"endpoints", []string{"-endpoint", "localhost:9090=buf.build/acme/user", "-endpoint", "localhost:9091=buf.build/acme/order", "-tls"}, false},
+		{"unknown failing with percentage", []string{"-fail-on", "unknown", "-max-failures", "10%"}, false},
+		{"json output", []string{"-output", "json"}, false},
+		{"invalid fail-on", []string{"-fail-on", "drift"}, true},
+		{"invalid count", []string{"-max-failures", "-1"}, true},
+		{"invalid percentage", []string{"-max-failures", "150%"}, true},
+		{"invalid output", []string{"-output", "yaml"}, true},
+		{"endpoint without module", []string{"-endpoint", "localhost:9090"}, true},
+		{"endpoint without port", []string{"-endpoint", "localhost=buf.build/acme/user"}, true},
+		{"endpoint with filters", []string{"-endpoint", "localhost:9090=buf.build/acme/user", "-namespace", "users"}, true},
+		{"unexpected arguments", []string{"users"}, true},
+		{"unknown flag", []string{"-color"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCheckFlags(tt.args, io.Discard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCheckFlags(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCheckFlagsAppliesTLSToEndpoints(t *testing.T) {
+	opts, err := parseCheckFlags([]string{
+		"-endpoint", "localhost:9090=buf.build/acme/user",
+		"-endpoint", "localhost:9091=buf.build/acme/order",
+		"-tls", "-server-name", "api.example.com", "-strict",
+	}, io.Discard)
+	if err != nil {
+		t.Fatalf("parseCheckFlags() error = %v", err)
+	}
+	if len(opts.endpoints) != 2 {
+		t.Fatalf("got %d endpoints, want 2", len(opts.endpoints))
+	}
+	for _, endpoint := range opts.endpoints {
+		if !endpoint.TLS || endpoint.ServerName != "api.example.com" || !endpoint.Strict || endpoint.InsecureSkipVerify {
+			t.Errorf("endpoint %+v, want TLS with server name api.example.com and strict checks", endpoint)
+		}
+	}
+}
@@ -27,6 +27,22 @@
 //   - SCAN_INTERVAL: Time duration between scan cycles
 //   - BSR_TOKEN: Authentication token for BSR API access
 //   - USE_MOCK_BSR: Set to "true" to use mock BSR client (for testing)
+//   - KUBECONFIG: Kubeconfig files for running outside the cluster (or the -kubeconfig flag)
+//   - KUBE_CONTEXT: Kubeconfig context to use
+//   - PORT_FORWARD: Set to "true" to reach pods through API server port-forwarding
+//   - CLUSTER_NAME: Name shown for the cluster protodiff runs against
+//   - CLUSTERS: Additional clusters to scan from kubeconfig contexts or Secrets
+//   - SERVICE_NAME_LABEL, DISCOVERY_SELECTOR: Labels used to discover gRPC pods
+//   - INCLUDE_NAMESPACES, EXCLUDE_NAMESPACES: Namespaces to scan or skip
+//   - DISCOVERY_MODE: Set to "services" to discover backends through Services
+//   - RECORD_EVENTS: Set to "false" to stop recording Kubernetes Events on drift
+//   - ALERTS_CONFIG: Path of a YAML file configuring alert webhooks and the email digest
+//   - LEADER_ELECTION: Set to "true" so only the replica holding the Lease scans
+//   - LEASE_NAME, LEASE_NAMESPACE: Location of the leader election Lease
+//   - POD_NAME, ADVERTISE_ADDR: Replica identity and address for result replication
+//   - SHARDING, SHARD_BY: Split scanning across replicas by pod or by service
+//   - STORE_BACKEND, STORE_PATH: Set to "bolt" to persist results to a file across restarts
+//   - HISTORY_RETENTION, HISTORY_LIMIT: How much drift history to keep
 //
 // Example usage:
 //
@@ -35,10 +51,18 @@
 //	protodiff
 //
 // The web dashboard will be available at http://localhost:18080
+//
+// The check subcommand validates once instead, prints the results and exits
+// non-zero on drift, for CI pipelines:
+//
+//	protodiff check -namespace payments -max-failures 10%
+//	protodiff check -endpoint localhost:9090=buf.build/acme/user
 package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -49,31 +73,49 @@ import (
 	"github.com/uzdada/protodiff/internal/adapters/grpc"
 	"github.com/uzdada/protodiff/internal/adapters/k8s"
 	"github.com/uzdada/protodiff/internal/adapters/web"
+	"github.com/uzdada/protodiff/internal/alerting"
 	"github.com/uzdada/protodiff/internal/config"
 	"github.com/uzdada/protodiff/internal/core/store"
+	"github.com/uzdada/protodiff/internal/ha"
 	"github.com/uzdada/protodiff/internal/scanner"
 )
 
 const (
 	// Graceful shutdown timeout
 	gracefulShutdownTimeout = 2 * time.Second
+	// Timeout for reading cluster kubeconfig Secrets at startup
+	clusterSetupTimeout = 10 * time.Second
+	// Shard key of the email digest, so exactly one replica sends it
+	digestShardKey = "digest"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		os.Exit(runCheck(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	kubeconfig := flag.String("kubeconfig", "", "Path to a kubeconfig file, overriding $KUBECONFIG")
+	flag.Parse()
+
 	log.Println("Starting ProtoDiff - gRPC Schema Drift Monitor")
 
 	// Load configuration from environment variables
 	cfg := config.Load()
+	cfg.Kubeconfig = *kubeconfig
 
 	// Initialize core components
-	dataStore := store.New()
+	dataStore, closeStore, err := openStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	defer closeStore()
 
-	// Initialize Kubernetes client
-	k8sClient, err := k8s.NewClient()
+	// Initialize Kubernetes clients for the home and additional clusters
+	clusters, err := newClusters(cfg)
 	if err != nil {
-		log.Fatalf("Failed to create Kubernetes client: %v", err)
+		log.Fatalf("Failed to initialize Kubernetes clients: %v", err)
 	}
-	log.Println("Kubernetes client initialized")
+	k8sClient := clusters[0]
 
 	// Initialize gRPC reflection client
 	grpcClient := grpc.NewReflectionClient()
@@ -91,21 +133,110 @@ func main() {
 
 	// Initialize scanner
 	scannerInstance := scanner.NewScanner(
-		k8sClient,
+		clusters,
 		grpcClient,
 		bsrClient,
 		dataStore,
 		cfg,
 	)
+	scannerInstance.SetProgressReporter(webServer)
+	scannerInstance.SetMetricsRecorder(webServer)
+
+	// Initialize alerting and the email digest
+	var alerter *alerting.Alerter
+	var digester *alerting.Digester
+	if cfg.AlertsConfig != "" {
+		alertsConfig, err := alerting.LoadConfig(cfg.AlertsConfig)
+		if err != nil {
+			log.Fatalf("Failed to load alerting config: %v", err)
+		}
+		if len(alertsConfig.Webhooks) > 0 {
+			alerter = alerting.NewAlerter(alertsConfig, dataStore)
+			scannerInstance.SetAlertNotifier(alerter)
+		}
+		if alertsConfig.Digest != nil {
+			digester = alerting.NewDigester(alertsConfig, dataStore)
+		}
+	}
 
 	// Setup context and signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if alerter != nil {
+		go alerter.Run(ctx)
+	}
+
 	// Handle shutdown signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// Start scanner in goroutine. With leader election only the leader scans,
+	// and followers mirror its results for their dashboard. With sharding every
+	// replica scans its share and merges the results of the others.
+	switch {
+	case cfg.Sharding:
+		membership := ha.NewMembership(k8sClient, dataStore, ha.ShardOptions{
+			Namespace: cfg.LeaseNamespace,
+			Group:     cfg.LeaseName,
+			Name:      cfg.PodName,
+			Identity:  ha.Identity(cfg.PodName, cfg.AdvertiseAddr),
+		})
+		joinCtx, joinCancel := context.WithTimeout(ctx, clusterSetupTimeout)
+		if err := membership.Join(joinCtx); err != nil {
+			log.Fatalf("Failed to join shard group: %v", err)
+		}
+		joinCancel()
+		if cfg.AdvertiseAddr == "" {
+			log.Println("Warning: ADVERTISE_ADDR is not set, other replicas can't merge results from this replica")
+		}
+		scannerInstance.SetShard(membership)
+		webServer.SetRescanner(scannerInstance)
+		if digester != nil {
+			digester.SetGate(func() bool { return membership.Owns(digestShardKey) })
+		}
+
+		go membership.Run(ctx)
+		go func() {
+			if err := scannerInstance.Start(ctx); err != nil && err != context.Canceled {
+				log.Printf("Scanner error: %v", err)
+			}
+		}()
+	case cfg.LeaderElection:
+		elector := ha.NewElector(k8sClient, ha.ElectorOptions{
+			Namespace: cfg.LeaseNamespace,
+			Name:      cfg.LeaseName,
+			Identity:  ha.Identity(cfg.PodName, cfg.AdvertiseAddr),
+		})
+		if cfg.AdvertiseAddr == "" {
+			log.Println("Warning: ADVERTISE_ADDR is not set, followers can't replicate results from this replica")
+		}
+		webServer.SetRescanner(ha.NewLeaderRescanner(elector, scannerInstance))
+		if digester != nil {
+			digester.SetGate(elector.IsLeader)
+		}
+
+		go func() {
+			if err := elector.Run(ctx, scannerInstance.Start); err != nil && err != context.Canceled {
+				log.Printf("Leader election error: %v", err)
+			}
+		}()
+		go ha.NewReplicator(elector, dataStore).Run(ctx)
+	default:
+		webServer.SetRescanner(scannerInstance)
+		go func() {
+			if err := scannerInstance.Start(ctx); err != nil {
+				if err != context.Canceled {
+					log.Printf("Scanner error: %v", err)
+				}
+			}
+		}()
+	}
+
+	if digester != nil {
+		go digester.Run(ctx)
+	}
+
 	// Start web server in goroutine
 	go func() {
 		log.Printf("Starting web server on %s", cfg.WebAddr)
@@ -114,15 +245,6 @@ func main() {
 		}
 	}()
 
-	// Start scanner in goroutine
-	go func() {
-		if err := scannerInstance.Start(ctx); err != nil {
-			if err != context.Canceled {
-				log.Printf("Scanner error: %v", err)
-			}
-		}
-	}()
-
 	log.Println("ProtoDiff is running. Press Ctrl+C to stop.")
 
 	// Wait for shutdown signal
@@ -135,5 +257,93 @@ func main() {
 	// Give goroutines time to cleanup
 	time.Sleep(gracefulShutdownTimeout)
 
+	// Flush pending Kubernetes Events
+	for _, cluster := range clusters {
+		cluster.Close()
+	}
+
 	log.Println("ProtoDiff stopped")
 }
+
+// openStore creates the configured result store and a function releasing it
+func openStore(cfg config.Config) (store.Store, func(), error) {
+	opts := store.Options{
+		HistoryRetention: cfg.HistoryRetention,
+		HistoryLimit:     cfg.HistoryLimit,
+	}
+	if cfg.StoreBackend != config.StoreBackendBolt {
+		return store.NewMemory(opts), func() {}, nil
+	}
+
+	boltStore, err := store.NewBolt(cfg.StorePath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeStore := func() {
+		if err := boltStore.Close(); err != nil {
+			log.Printf("Warning: Failed to close store: %v", err)
+		}
+	}
+	return boltStore, closeStore, nil
+}
+
+// newClusters creates the clients of the home cluster and the additional clusters
+func newClusters(cfg config.Config) ([]*k8s.Client, error) {
+	k8sClient, err := k8s.NewClient(k8s.Options{
+		Name:       cfg.ClusterName,
+		Kubeconfig: cfg.Kubeconfig,
+		Context:    cfg.KubeContext,
+		Discovery:  discoveryOptions(cfg),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	log.Println("Kubernetes client initialized")
+
+	clusters := []*k8s.Client{k8sClient}
+	for _, clusterCfg := range cfg.Clusters {
+		clusterClient, err := newClusterClient(k8sClient, cfg, clusterCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client for cluster %s: %w", clusterCfg.Name, err)
+		}
+		clusters = append(clusters, clusterClient)
+		log.Printf("Kubernetes client initialized for cluster %s", clusterCfg.Name)
+	}
+	return clusters, nil
+}
+
+// newClusterClient creates a client for an additional cluster, reading its
+// kubeconfig either from the local kubeconfig contexts or from a Secret in the home cluster.
+func newClusterClient(home *k8s.Client, cfg config.Config, clusterCfg config.ClusterConfig) (*k8s.Client, error) {
+	if clusterCfg.SecretName == "" {
+		return k8s.NewClient(k8s.Options{
+			Name:       clusterCfg.Name,
+			Kubeconfig: cfg.Kubeconfig,
+			Context:    clusterCfg.Context,
+			Discovery:  discoveryOptions(cfg),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clusterSetupTimeout)
+	defer cancel()
+
+	data, err := home.GetKubeconfigSecret(ctx, clusterCfg.SecretNamespace, clusterCfg.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	return k8s.NewClient(k8s.Options{
+		Name:           clusterCfg.Name,
+		KubeconfigData: data,
+		Discovery:      discoveryOptions(cfg),
+	})
+}
+
+// discoveryOptions builds the pod discovery options shared by all clusters
+func discoveryOptions(cfg config.Config) k8s.DiscoveryOptions {
+	return k8s.DiscoveryOptions{
+		ServiceNameLabel:  cfg.ServiceNameLabel,
+		Selector:          cfg.DiscoverySelector,
+		IncludeNamespaces: cfg.IncludeNamespaces,
+		ExcludeNamespaces: cfg.ExcludeNamespaces,
+	}
+}
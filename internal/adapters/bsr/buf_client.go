@@ -29,13 +29,20 @@ package bsr
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/uzdada/protodiff/internal/adapters/protoset"
 	"github.com/uzdada/protodiff/internal/core/domain"
 )
 
@@ -69,7 +76,7 @@ func (c *BufClient) FetchSchema(ctx context.Context, module string) (*domain.Sch
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("buf export failed: %w (output: %s)", err, string(output))
+		return nil, bufCommandError(ctx, "buf export", err, output)
 	}
 
 	// Parse exported proto files
@@ -125,8 +132,76 @@ func (c *BufClient) FetchSchema(ctx context.Context, module string) (*domain.Sch
 		return nil, fmt.Errorf("failed to parse proto files: %w", err)
 	}
 
-	// Convert to SchemaDescriptor
-	return fileDescriptorsToSchema(fileDescs), nil
+	// Convert to SchemaDescriptor, keeping the parsed files for download
+	schema := fileDescriptorsToSchema(fileDescs)
+	if data, err := protoset.FromFiles(fileDescs); err != nil {
+		log.Printf("Warning: Failed to serialize BSR schema of %s: %v", module, err)
+	} else {
+		schema.FileDescriptorSet = data
+	}
+	return schema, nil
+}
+
+// ResolveCommit resolves a module reference to its current BSR commit using
+// `buf beta registry commit get`
+func (c *BufClient) ResolveCommit(ctx context.Context, module string) (string, error) {
+	cmd := exec.CommandContext(ctx, "buf", "beta", "registry", "commit", "get", module, "--format", "json")
+	if c.token != "" {
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", envBSRToken, c.token))
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			output = exitErr.Stderr
+		}
+		return "", bufCommandError(ctx, "buf registry commit get", err, output)
+	}
+
+	var commit struct {
+		Commit string `json:"commit"`
+	}
+	if err := json.Unmarshal(output, &commit); err != nil {
+		return "", fmt.Errorf("failed to parse commit info: %w", err)
+	}
+	if commit.Commit == "" {
+		return "", fmt.Errorf("no commit in buf output for %s", module)
+	}
+	return commit.Commit, nil
+}
+
+// bufFailurePattern matches the Connect error code buf CLI prints when a BSR
+// request fails, e.g. "Failure: unauthenticated: you must be authenticated"
+var bufFailurePattern = regexp.MustCompile(`\b(unauthenticated|permission_denied|not_found|resource_exhausted|deadline_exceeded|unavailable|internal):`)
+
+// bufErrorStatuses maps Connect error codes to the HTTP status the BSR API
+// answers with, so buf CLI failures classify like HTTP API failures
+var bufErrorStatuses = map[string]int{
+	"unauthenticated":    http.StatusUnauthorized,
+	"permission_denied":  http.StatusForbidden,
+	"not_found":          http.StatusNotFound,
+	"resource_exhausted": http.StatusTooManyRequests,
+	"unavailable":        http.StatusServiceUnavailable,
+	"internal":           http.StatusInternalServerError,
+}
+
+// bufCommandError describes a failed buf command. Timeouts wrap
+// context.DeadlineExceeded and BSR failures a StatusError, so callers can
+// tell them apart from other failures.
+func bufCommandError(ctx context.Context, command string, err error, output []byte) error {
+	message := strings.TrimSpace(string(output))
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%s failed: %w", command, ctxErr)
+	}
+	match := bufFailurePattern.FindStringSubmatch(message)
+	if match == nil {
+		return fmt.Errorf("%s failed: %w (output: %s)", command, err, message)
+	}
+	if match[1] == "deadline_exceeded" {
+		return fmt.Errorf("%s failed: %w (output: %s)", command, context.DeadlineExceeded, message)
+	}
+	return fmt.Errorf("%s failed: %w", command, &StatusError{StatusCode: bufErrorStatuses[match[1]], Body: message})
 }
 
 // fileDescriptorsToSchema converts file descriptors to domain SchemaDescriptor
@@ -160,5 +235,8 @@ func fileDescriptorsToSchema(fileDescs []*desc.FileDescriptor) *domain.SchemaDes
 	}
 }
 
-// Ensure BufClient implements Client interface
-var _ Client = (*BufClient)(nil)
+// Ensure BufClient implements Client and CommitResolver interfaces
+var (
+	_ Client         = (*BufClient)(nil)
+	_ CommitResolver = (*BufClient)(nil)
+)
@@ -0,0 +1,92 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bsr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeBuf installs a buf executable that prints output to stderr and exits
+// with status 1, or sleeps when output is empty
+func fakeBuf(t *testing.T, output string) {
+	t.Helper()
+	script := "#!/bin/sh\necho '" + output + "' >&2\nexit 1\n"
+	if output == "" {
+		script = "#!/bin/sh\nexec sleep 5\n"
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "buf"), []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write fake buf: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestBufClientClassifiesFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		status int
+	}{
+		{"unauthenticated", "Failure: unauthenticated: you must be authenticated", http.StatusUnauthorized},
+		{"permission denied", "Failure: permission_denied: no access to repository", http.StatusForbidden},
+		{"not found", `Failure: not_found: repository "buf.build/acme/missing" was not found`, http.StatusNotFound},
+		{"rate limited", "Failure: resource_exhausted: too many requests", http.StatusTooManyRequests},
+		{"unavailable", "Failure: unavailable: service unavailable", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeBuf(t, tt.output)
+			client := &BufClient{}
+
+			_, err := client.FetchSchema(context.Background(), "buf.build/acme/users")
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Errorf("FetchSchema() error = %v, want a StatusError with status %d", err, tt.status)
+			}
+
+			_, err = client.ResolveCommit(context.Background(), "buf.build/acme/users")
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Errorf("ResolveCommit() error = %v, want a StatusError with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestBufClientTimeout(t *testing.T) {
+	fakeBuf(t, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := (&BufClient{}).FetchSchema(ctx, "buf.build/acme/users")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FetchSchema() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestBufClientUnclassifiedFailure(t *testing.T) {
+	fakeBuf(t, "Failure: could not parse module reference")
+
+	_, err := (&BufClient{}).FetchSchema(context.Background(), "not a module")
+	var statusErr *StatusError
+	if err == nil || errors.As(err, &statusErr) || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FetchSchema() error = %v, want an unclassified error", err)
+	}
+}
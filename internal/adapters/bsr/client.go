@@ -28,6 +28,7 @@ package bsr
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/uzdada/protodiff/internal/core/domain"
 )
@@ -37,3 +38,26 @@ type Client interface {
 	// FetchSchema retrieves the schema definition from BSR for a given module
 	FetchSchema(ctx context.Context, module string) (*domain.SchemaDescriptor, error)
 }
+
+// CommitResolver is implemented by clients that can resolve a module reference
+// to the BSR commit it currently points at
+type CommitResolver interface {
+	// ResolveCommit returns the commit ID for a module reference (e.g. buf.build/acme/user or buf.build/acme/user:main)
+	ResolveCommit(ctx context.Context, module string) (string, error)
+}
+
+// StatusError is returned when the BSR API answers with an unexpected HTTP status.
+// BufClient returns it as well when buf CLI reports a failed BSR request, with
+// the HTTP status matching the reported error code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("BSR API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("BSR API returned status %d: %s", e.StatusCode, e.Body)
+}
@@ -32,6 +32,7 @@ import (
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/uzdada/protodiff/internal/core/domain"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -114,9 +115,9 @@ func (c *HTTPClient) FetchSchema(ctx context.Context, module string) (*domain.Sc
 	if resp.StatusCode != http.StatusOK {
 		body, readErr := io.ReadAll(resp.Body)
 		if readErr != nil {
-			return nil, fmt.Errorf("BSR API returned status %d (unable to read response body: %w)", resp.StatusCode, readErr)
+			return nil, fmt.Errorf("%w (unable to read response body: %v)", &StatusError{StatusCode: resp.StatusCode}, readErr)
 		}
-		return nil, fmt.Errorf("BSR API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse response
@@ -161,6 +162,10 @@ func (c *HTTPClient) FetchSchema(ctx context.Context, module string) (*domain.Sc
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert FileDescriptorSet: %w", err)
 	}
+	schema.FileDescriptorSet, err = proto.MarshalOptions{Deterministic: true}.Marshal(responseData.FileDescriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal FileDescriptorSet: %w", err)
+	}
 
 	return schema, nil
 }
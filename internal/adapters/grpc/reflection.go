@@ -20,22 +20,27 @@
 // proto files. This package leverages reflection to fetch schemas from running pods.
 //
 // The ReflectionClient connects to a gRPC server, queries available services,
-// and converts the discovered schema into domain.SchemaDescriptor format.
+// and converts the discovered schema into domain.SchemaDescriptor format, keeping
+// the files it resolved as a serialized FileDescriptorSet.
 //
 // Example usage:
 //
 //	client := grpc.NewReflectionClient()
-//	schema, err := client.FetchSchema(ctx, "10.0.1.5:9090")
+//	schema, err := client.FetchSchema(ctx, "10.0.1.5:9090", grpc.ProbeOptions{})
 package grpc
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 
+	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/grpcreflect"
+	"github.com/uzdada/protodiff/internal/adapters/protoset"
 	"github.com/uzdada/protodiff/internal/core/domain"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	reflectpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
@@ -54,12 +59,54 @@ func NewReflectionClient() *ReflectionClient {
 	return &ReflectionClient{}
 }
 
+// ProbeOptions controls how the reflection client connects to a pod
+type ProbeOptions struct {
+	// TLS enables transport security
+	TLS bool
+	// InsecureSkipVerify disables certificate verification when TLS is enabled
+	InsecureSkipVerify bool
+	// ServerName overrides the name used to verify the server certificate
+	ServerName string
+}
+
+// transportCredentials returns the gRPC transport credentials for the options
+func (o ProbeOptions) transportCredentials() credentials.TransportCredentials {
+	if !o.TLS {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(&tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify, //nolint:gosec // opt-in per pod for self-signed certificates
+	})
+}
+
+// Probe checks whether a reflection endpoint answers at the address.
+// It only lists services, making it cheap enough to try several candidate ports.
+func (r *ReflectionClient) Probe(ctx context.Context, address string, opts ProbeOptions) error {
+	conn, err := grpc.Dial(
+		address,
+		grpc.WithTransportCredentials(opts.transportCredentials()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	refClient := grpcreflect.NewClientV1Alpha(ctx, reflectpb.NewServerReflectionClient(conn))
+	defer refClient.Reset()
+
+	if _, err := refClient.ListServices(); err != nil {
+		return fmt.Errorf("no reflection endpoint at %s: %w", address, err)
+	}
+	return nil
+}
+
 // FetchSchema retrieves the schema from a gRPC server using reflection
-func (r *ReflectionClient) FetchSchema(ctx context.Context, address string) (*domain.SchemaDescriptor, error) {
+func (r *ReflectionClient) FetchSchema(ctx context.Context, address string, opts ProbeOptions) (*domain.SchemaDescriptor, error) {
 	// Connect to the gRPC server
 	conn, err := grpc.Dial(
 		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(opts.transportCredentials()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
@@ -82,6 +129,7 @@ func (r *ReflectionClient) FetchSchema(ctx context.Context, address string) (*do
 	}
 
 	// Extract service and method information
+	var files []*desc.FileDescriptor
 	for _, serviceName := range services {
 		// Skip the reflection service itself
 		if serviceName == reflectionServiceName {
@@ -103,6 +151,14 @@ func (r *ReflectionClient) FetchSchema(ctx context.Context, address string) (*do
 			Name:    serviceName,
 			Methods: methods,
 		})
+		files = append(files, serviceDesc.GetFile())
+	}
+
+	// Keep the resolved files so the live schema can be downloaded
+	if data, err := protoset.FromFiles(files); err != nil {
+		log.Printf("Warning: Failed to serialize schema of %s: %v", address, err)
+	} else {
+		schema.FileDescriptorSet = data
 	}
 
 	return schema, nil
@@ -0,0 +1,141 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pod annotations that let workloads override the central ConfigMap
+const (
+	// AnnotationModule sets the BSR module for the pod
+	AnnotationModule = "protodiff.io/module"
+	// AnnotationPort sets the gRPC reflection port
+	AnnotationPort = "protodiff.io/port"
+	// AnnotationTLS enables TLS for reflection: "true", "false" or "insecure"
+	// ("insecure" skips certificate verification)
+	AnnotationTLS = "protodiff.io/tls"
+	// AnnotationTLSServerName sets the server name used to verify the pod certificate
+	AnnotationTLSServerName = "protodiff.io/tls-server-name"
+	// AnnotationIgnoreServices is a comma-separated list of gRPC services to leave
+	// out of the comparison. A trailing "*" matches by prefix.
+	AnnotationIgnoreServices = "protodiff.io/ignore-services"
+	// AnnotationSkip excludes the pod from scanning when set to "true"
+	AnnotationSkip = "protodiff.io/skip"
+	// AnnotationOwner names the team or person owning the service, used to route alerts
+	AnnotationOwner = "protodiff.io/owner"
+
+	tlsInsecureValue = "insecure"
+)
+
+// PodOverrides holds per-pod settings declared through protodiff.io annotations
+type PodOverrides struct {
+	// Module overrides the BSR module from the ConfigMap
+	Module string
+	// Port overrides the detected gRPC port (0 means not set)
+	Port int32
+	// TLS enables TLS for the reflection connection
+	TLS bool
+	// TLSInsecure skips certificate verification
+	TLSInsecure bool
+	// TLSServerName is the expected server name in the pod certificate
+	TLSServerName string
+	// IgnoreServices lists services excluded from the comparison
+	IgnoreServices []string
+	// Skip excludes the pod from scanning
+	Skip bool
+	// Owner is the team or person owning the service
+	Owner string
+}
+
+// parsePodOverrides reads protodiff.io annotations from an object's metadata
+// (a Pod, or a Service in service discovery mode). Invalid values are logged and ignored.
+func parsePodOverrides(meta metav1.ObjectMeta) PodOverrides {
+	annotations := meta.Annotations
+	overrides := PodOverrides{
+		Module:        strings.TrimSpace(annotations[AnnotationModule]),
+		TLSServerName: strings.TrimSpace(annotations[AnnotationTLSServerName]),
+		Owner:         strings.TrimSpace(annotations[AnnotationOwner]),
+	}
+
+	if value, ok := annotations[AnnotationPort]; ok {
+		port, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil || port <= 0 || port > 65535 {
+			log.Printf("Warning: Invalid %s annotation '%s' on %s/%s", AnnotationPort, value, meta.Namespace, meta.Name)
+		} else {
+			overrides.Port = int32(port)
+		}
+	}
+
+	if value, ok := annotations[AnnotationTLS]; ok {
+		value = strings.TrimSpace(value)
+		if strings.EqualFold(value, tlsInsecureValue) {
+			overrides.TLS = true
+			overrides.TLSInsecure = true
+		} else if enabled, err := strconv.ParseBool(value); err == nil {
+			overrides.TLS = enabled
+		} else {
+			log.Printf("Warning: Invalid %s annotation '%s' on %s/%s", AnnotationTLS, value, meta.Namespace, meta.Name)
+		}
+	}
+
+	if value, ok := annotations[AnnotationSkip]; ok {
+		skip, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			log.Printf("Warning: Invalid %s annotation '%s' on %s/%s", AnnotationSkip, value, meta.Namespace, meta.Name)
+		}
+		overrides.Skip = skip
+	}
+
+	for _, service := range strings.Split(annotations[AnnotationIgnoreServices], ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			overrides.IgnoreServices = append(overrides.IgnoreServices, service)
+		}
+	}
+
+	return overrides
+}
+
+// mergePodOverrides layers the protodiff.io annotations set on a Service's
+// endpoint pod over the Service's own. The port is left alone: it is resolved
+// from the Service and its EndpointSlices.
+func mergePodOverrides(overrides PodOverrides, pod metav1.ObjectMeta) PodOverrides {
+	podOverrides := parsePodOverrides(pod)
+	annotations := pod.Annotations
+	if _, ok := annotations[AnnotationModule]; ok {
+		overrides.Module = podOverrides.Module
+	}
+	if _, ok := annotations[AnnotationTLS]; ok {
+		overrides.TLS = podOverrides.TLS
+		overrides.TLSInsecure = podOverrides.TLSInsecure
+	}
+	if _, ok := annotations[AnnotationTLSServerName]; ok {
+		overrides.TLSServerName = podOverrides.TLSServerName
+	}
+	if _, ok := annotations[AnnotationIgnoreServices]; ok {
+		overrides.IgnoreServices = podOverrides.IgnoreServices
+	}
+	if _, ok := annotations[AnnotationSkip]; ok {
+		overrides.Skip = podOverrides.Skip
+	}
+	if _, ok := annotations[AnnotationOwner]; ok {
+		overrides.Owner = podOverrides.Owner
+	}
+	return overrides
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergePodOverrides(t *testing.T) {
+	service := parsePodOverrides(metav1.ObjectMeta{Annotations: map[string]string{
+		AnnotationModule: "buf.build/acme/users",
+		AnnotationPort:   "9000",
+		AnnotationTLS:    "true",
+		AnnotationOwner:  "team-users",
+	}})
+
+	got := mergePodOverrides(service, metav1.ObjectMeta{Annotations: map[string]string{
+		AnnotationModule: "buf.build/acme/users-canary",
+		AnnotationPort:   "9999",
+		AnnotationSkip:   "true",
+	}})
+	want := PodOverrides{
+		Module: "buf.build/acme/users-canary",
+		Port:   9000,
+		TLS:    true,
+		Skip:   true,
+		Owner:  "team-users",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePodOverrides() = %+v, want %+v", got, want)
+	}
+
+	if got := mergePodOverrides(service, metav1.ObjectMeta{}); !reflect.DeepEqual(got, service) {
+		t.Errorf("mergePodOverrides() without pod annotations = %+v, want %+v", got, service)
+	}
+}
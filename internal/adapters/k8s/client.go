@@ -17,16 +17,22 @@
 //
 // This package wraps the official Kubernetes client-go library to provide
 // application-specific operations:
-//   - Discovering pods labeled with grpc-service=true
+//   - Discovering pods matching a configurable opt-in selector (grpc-service=true by default)
 //   - Loading service-to-BSR mappings from ConfigMaps
+//   - Reading SchemaBinding custom resources and writing their status
+//   - Recording Events on pods and workloads
+//   - Reading per-pod overrides from protodiff.io annotations
+//   - Resolving the workload (Deployment, StatefulSet, DaemonSet) owning each pod
 //   - Retrieving pod network information for gRPC connections
 //
-// The client uses in-cluster configuration when running inside Kubernetes,
-// automatically authenticating via the service account token.
+// The client loads a kubeconfig when one is configured (optionally selecting a
+// specific context), and otherwise uses in-cluster configuration, automatically
+// authenticating via the service account token. When running outside the
+// cluster, pod ports can be reached through API server port-forwarding.
 //
 // Example usage:
 //
-//	client, err := k8s.NewClient()
+//	client, err := k8s.NewClient(k8s.Options{Context: "staging"})
 //	pods, err := client.DiscoverGRPCPods(ctx)
 //	mappings, err := client.LoadServiceMappings(ctx, "default", "config")
 package k8s
@@ -34,33 +40,95 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/uzdada/protodiff/internal/core/domain"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
-	// GRPCServiceLabel is the label used to identify gRPC-enabled pods
+	// GRPCServiceLabel is the default opt-in label used to identify gRPC-enabled pods
 	GRPCServiceLabel = "grpc-service"
-	// ServiceNameLabel is the label containing the logical service name
+	// ServiceNameLabel is the default label containing the logical service name
 	ServiceNameLabel = "app"
 	// DefaultGRPCPort is the default port for gRPC reflection
 	DefaultGRPCPort = 9090
+	// DefaultClusterName is the cluster name used when none is configured
+	DefaultClusterName = "default"
+	// KubeconfigSecretKey is the Secret data key holding a remote cluster's kubeconfig
+	KubeconfigSecretKey = "kubeconfig"
 )
 
-// Client provides Kubernetes API operations
+// Client provides Kubernetes API operations against a single cluster
 type Client struct {
-	clientset *kubernetes.Clientset
+	name       string
+	clientset  *kubernetes.Clientset
+	dynamic    dynamic.Interface
+	restConfig *rest.Config
+
+	// Discovery settings
+	serviceNameLabel  string
+	optInSelector     labels.Selector
+	explicitSelector  bool
+	includeNamespaces []string
+	excludeNamespaces map[string]bool
+
+	// Event recording, started on first use
+	eventsOnce       sync.Once
+	eventBroadcaster record.EventBroadcaster
+	recorder         record.EventRecorder
+}
+
+// DiscoveryOptions controls which pods are discovered and how they are named
+type DiscoveryOptions struct {
+	// ServiceNameLabel is the label holding the logical service name (default: "app")
+	ServiceNameLabel string
+	// Selector is the opt-in label selector in full selector syntax
+	// (default: "grpc-service=true"). When set explicitly, it also filters
+	// ConfigMap-based discovery.
+	Selector string
+	// IncludeNamespaces limits discovery to these namespaces (default: all)
+	IncludeNamespaces []string
+	// ExcludeNamespaces skips pods in these namespaces
+	ExcludeNamespaces []string
+}
+
+// Options configures how the client connects to the Kubernetes API server
+type Options struct {
+	// Name identifies the cluster in scan results and on the dashboard
+	Name string
+	// KubeconfigData is raw kubeconfig content, e.g. read from a Secret.
+	// When set it takes precedence over Kubeconfig.
+	KubeconfigData []byte
+	// Discovery configures pod discovery
+	Discovery DiscoveryOptions
+	// Kubeconfig is the path to a kubeconfig file, overriding the default
+	// loading rules ($KUBECONFIG as a colon-separated list, ~/.kube/config).
+	Kubeconfig string
+	// Context is the kubeconfig context to use.
+	// Empty means the current context of the kubeconfig.
+	Context string
 }
 
-// NewClient creates a new Kubernetes client using in-cluster configuration
-func NewClient() (*Client, error) {
-	config, err := rest.InClusterConfig()
+// NewClient creates a new Kubernetes client.
+// A kubeconfig is used when a path or context is given explicitly; otherwise
+// in-cluster configuration is tried first, falling back to the default
+// kubeconfig loading rules when not running inside a cluster.
+func NewClient(opts Options) (*Client, error) {
+	config, err := loadRESTConfig(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+		return nil, err
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
@@ -68,120 +136,258 @@ func NewClient() (*Client, error) {
 		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
 	}
 
-	return &Client{
-		clientset: clientset,
-	}, nil
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = DefaultClusterName
+	}
+
+	client := &Client{
+		name:       name,
+		clientset:  clientset,
+		dynamic:    dynamicClient,
+		restConfig: config,
+	}
+	if err := client.applyDiscoveryOptions(opts.Discovery); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// applyDiscoveryOptions validates discovery options and fills in defaults
+func (c *Client) applyDiscoveryOptions(opts DiscoveryOptions) error {
+	c.serviceNameLabel = opts.ServiceNameLabel
+	if c.serviceNameLabel == "" {
+		c.serviceNameLabel = ServiceNameLabel
+	}
+
+	selector := opts.Selector
+	c.explicitSelector = selector != ""
+	if !c.explicitSelector {
+		selector = fmt.Sprintf("%s=true", GRPCServiceLabel)
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid discovery selector %q: %w", selector, err)
+	}
+	c.optInSelector = parsed
+
+	c.includeNamespaces = opts.IncludeNamespaces
+	c.excludeNamespaces = make(map[string]bool, len(opts.ExcludeNamespaces))
+	for _, namespace := range opts.ExcludeNamespaces {
+		c.excludeNamespaces[namespace] = true
+	}
+	return nil
+}
+
+// Name returns the cluster name this client is connected to
+func (c *Client) Name() string {
+	return c.name
+}
+
+// loadRESTConfig resolves the REST config from a kubeconfig or the in-cluster environment
+func loadRESTConfig(opts Options) (*rest.Config, error) {
+	if len(opts.KubeconfigData) > 0 {
+		clientConfig, err := clientcmd.Load(opts.KubeconfigData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubeconfig data: %w", err)
+		}
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+		config, err := clientcmd.NewNonInteractiveClientConfig(*clientConfig, opts.Context, overrides, nil).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build config from kubeconfig data (context %q): %w", opts.Context, err)
+		}
+		return config, nil
+	}
+
+	if opts.Kubeconfig == "" && opts.Context == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
+		config, err := rest.InClusterConfig()
+		if err == nil {
+			return config, nil
+		}
+		if err != rest.ErrNotInCluster {
+			return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+		}
+	}
+
+	// The default rules merge the files listed in $KUBECONFIG
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if opts.Kubeconfig != "" {
+		loadingRules.ExplicitPath = opts.Kubeconfig
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig (context %q): %w", opts.Context, err)
+	}
+	return config, nil
 }
 
 // PodInfo contains information about a discovered gRPC pod
 type PodInfo struct {
-	Name        string
-	Namespace   string
+	Name      string
+	Namespace string
+	// UID is the pod's Kubernetes UID (empty for endpoints without a pod)
+	UID         types.UID
 	ServiceName string
 	IP          string
 	GRPCPort    int32
+	// PortSource is the detection rule that selected GRPCPort
+	PortSource domain.PortSource
+	// Workload is the controller owning the pod (nil for bare pods)
+	Workload *domain.WorkloadRef
+	// Image is the image reference of the container serving gRPC
+	Image string
+	// ImageDigest is the resolved digest of Image (empty until pulled)
+	ImageDigest string
+	// KubeService is the Kubernetes Service the pod was reached through
+	// (service discovery mode only)
+	KubeService string
+	// CandidatePorts are the ports worth probing for a reflection endpoint
+	// when no rule identified the gRPC port (PortSource is PortSourceDefault)
+	CandidatePorts []int32
+	// Overrides are per-pod settings from protodiff.io annotations
+	Overrides PodOverrides
+	// Binding is the namespace/name of the SchemaBinding selecting the pod
+	// (empty when discovered through the ConfigMap or labels)
+	Binding string
+	// StrictComparison treats missing and extra services as drift
+	StrictComparison bool
 }
 
-// DiscoverGRPCPods finds all pods labeled with grpc-service=true
+// DiscoverGRPCPods finds all pods matching the opt-in selector (grpc-service=true by default)
 // Deprecated: Use DiscoverPodsForServices for ConfigMap-based discovery
 func (c *Client) DiscoverGRPCPods(ctx context.Context) ([]PodInfo, error) {
-	pods, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=true", GRPCServiceLabel),
-	})
+	pods, err := c.listPods(ctx, c.optInSelector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
+	resolver := newDiscoveryResolver(c)
 	var podInfos []PodInfo
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		// Skip pods that are not running
 		if pod.Status.Phase != corev1.PodRunning {
 			continue
 		}
 
 		// Extract service name from labels
-		serviceName := pod.Labels[ServiceNameLabel]
+		serviceName := pod.Labels[c.serviceNameLabel]
 		if serviceName == "" {
 			serviceName = "unknown"
 		}
 
-		// Determine gRPC port from container ports, fallback to 9090
-		grpcPort := int32(DefaultGRPCPort)
-		if len(pod.Spec.Containers) > 0 {
-			for _, container := range pod.Spec.Containers {
-				for _, port := range container.Ports {
-					if port.Name == "grpc" || port.Protocol == corev1.ProtocolTCP {
-						grpcPort = port.ContainerPort
-						break
-					}
-				}
-				if grpcPort != DefaultGRPCPort {
-					break
-				}
-			}
-		}
-
-		podInfos = append(podInfos, PodInfo{
-			Name:        pod.Name,
-			Namespace:   pod.Namespace,
-			ServiceName: serviceName,
-			IP:          pod.Status.PodIP,
-			GRPCPort:    grpcPort,
-		})
+		podInfos = append(podInfos, c.newPodInfo(ctx, pod, serviceName, resolver))
 	}
 
 	return podInfos, nil
 }
 
 // DiscoverPodsForServices finds pods for specific service names from ConfigMap
-// This is more efficient than label-based discovery when you have explicit service mappings
+// This is more efficient than label-based discovery when you have explicit service mappings.
+// When an opt-in selector is configured explicitly, pods must match it as well.
 func (c *Client) DiscoverPodsForServices(ctx context.Context, serviceNames []string) ([]PodInfo, error) {
+	resolver := newDiscoveryResolver(c)
 	var podInfos []PodInfo
 
 	for _, serviceName := range serviceNames {
-		// Find pods with app=serviceName label across all namespaces
-		pods, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-			LabelSelector: fmt.Sprintf("%s=%s", ServiceNameLabel, serviceName),
-		})
+		requirement, err := labels.NewRequirement(c.serviceNameLabel, selection.Equals, []string{serviceName})
+		if err != nil {
+			return nil, fmt.Errorf("invalid service name %q for label %s: %w", serviceName, c.serviceNameLabel, err)
+		}
+		selector := labels.NewSelector().Add(*requirement)
+		if c.explicitSelector {
+			requirements, _ := c.optInSelector.Requirements()
+			selector = selector.Add(requirements...)
+		}
+
+		// Find pods with the service name label across the selected namespaces
+		pods, err := c.listPods(ctx, selector)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list pods for service %s: %w", serviceName, err)
 		}
 
-		for _, pod := range pods.Items {
+		for _, pod := range pods {
 			// Skip pods that are not running
 			if pod.Status.Phase != corev1.PodRunning {
 				continue
 			}
 
-			// Determine gRPC port from container ports, fallback to 9090
-			grpcPort := int32(DefaultGRPCPort)
-			if len(pod.Spec.Containers) > 0 {
-				for _, container := range pod.Spec.Containers {
-					for _, port := range container.Ports {
-						if port.Name == "grpc" || port.Protocol == corev1.ProtocolTCP {
-							grpcPort = port.ContainerPort
-							break
-						}
-					}
-					if grpcPort != DefaultGRPCPort {
-						break
-					}
-				}
-			}
-
-			podInfos = append(podInfos, PodInfo{
-				Name:        pod.Name,
-				Namespace:   pod.Namespace,
-				ServiceName: serviceName,
-				IP:          pod.Status.PodIP,
-				GRPCPort:    grpcPort,
-			})
+			podInfos = append(podInfos, c.newPodInfo(ctx, pod, serviceName, resolver))
 		}
 	}
 
 	return podInfos, nil
 }
 
+// newPodInfo builds a PodInfo for a running pod
+func (c *Client) newPodInfo(ctx context.Context, pod corev1.Pod, serviceName string, resolver *discoveryResolver) PodInfo {
+	overrides := parsePodOverrides(pod.ObjectMeta)
+	detection := detectGRPCPort(pod, overrides, resolver.services.forPod(ctx, pod))
+	image, digest := containerImage(pod, detection.port)
+
+	return PodInfo{
+		Name:           pod.Name,
+		Namespace:      pod.Namespace,
+		UID:            pod.UID,
+		ServiceName:    serviceName,
+		IP:             pod.Status.PodIP,
+		GRPCPort:       detection.port,
+		PortSource:     detection.source,
+		CandidatePorts: detection.candidates,
+		Workload:       resolver.workloads.resolve(ctx, pod),
+		Image:          image,
+		ImageDigest:    digest,
+		Overrides:      overrides,
+	}
+}
+
+// discoveryResolver bundles the per-pass caches used while building PodInfos
+type discoveryResolver struct {
+	services  *serviceCache
+	workloads *workloadResolver
+}
+
+// newDiscoveryResolver creates fresh caches for a discovery pass
+func newDiscoveryResolver(c *Client) *discoveryResolver {
+	return &discoveryResolver{
+		services:  newServiceCache(c),
+		workloads: newWorkloadResolver(c),
+	}
+}
+
+// listPods lists pods matching the selector in all namespaces allowed by the
+// include/exclude lists. With an include list, only those namespaces are queried.
+func (c *Client) listPods(ctx context.Context, selector labels.Selector) ([]corev1.Pod, error) {
+	namespaces := c.includeNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var pods []corev1.Pod
+	for _, namespace := range namespaces {
+		list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range list.Items {
+			if c.excludeNamespaces[pod.Namespace] {
+				continue
+			}
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
 // GetConfigMap retrieves a ConfigMap from the specified namespace.
 // It uses the Kubernetes client to fetch the ConfigMap by name and returns
 // an error if the ConfigMap does not exist or cannot be accessed.
@@ -196,6 +402,28 @@ func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*cor
 	return cm, nil
 }
 
+// Leases returns the Lease client for a namespace, used for leader election
+func (c *Client) Leases(namespace string) coordinationv1.LeaseInterface {
+	return c.clientset.CoordinationV1().Leases(namespace)
+}
+
+// GetKubeconfigSecret reads kubeconfig content stored under the "kubeconfig" key of a Secret.
+// It is used to connect to additional clusters in multi-cluster mode.
+func (c *Client) GetKubeconfigSecret(ctx context.Context, namespace, name string) ([]byte, error) {
+	secret, err := c.clientset.CoreV1().
+		Secrets(namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	data, ok := secret.Data[KubeconfigSecretKey]
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", namespace, name, KubeconfigSecretKey)
+	}
+	return data, nil
+}
+
 // LoadServiceMappings loads service-to-BSR mappings from a ConfigMap
 func (c *Client) LoadServiceMappings(ctx context.Context, namespace, configMapName string) (domain.ServiceMappings, error) {
 	cm, err := c.GetConfigMap(ctx, namespace, configMapName)
@@ -0,0 +1,98 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"github.com/uzdada/protodiff/internal/core/domain"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventComponent is the source component of the Events ProtoDiff records
+const EventComponent = "protodiff"
+
+// Reasons of the Events recorded on status transitions
+const (
+	EventReasonDriftDetected    = "SchemaDriftDetected"
+	EventReasonDriftResolved    = "SchemaDriftResolved"
+	EventReasonValidationFailed = "SchemaValidationFailed"
+)
+
+// workloadAPIVersions maps the workload kinds Events are recorded on to their API version
+var workloadAPIVersions = map[string]string{
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"DaemonSet":   "apps/v1",
+	"ReplicaSet":  "apps/v1",
+}
+
+// eventRecorder returns the client's Event recorder, starting the broadcaster
+// on first use. The broadcaster's correlator deduplicates identical events into
+// a single Event with an increasing count and aggregates bursts of similar ones.
+func (c *Client) eventRecorder() record.EventRecorder {
+	c.eventsOnce.Do(func() {
+		c.eventBroadcaster = record.NewBroadcaster()
+		c.eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+			Interface: c.clientset.CoreV1().Events(""),
+		})
+		c.recorder = c.eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: EventComponent})
+	})
+	return c.recorder
+}
+
+// RecordPodEvent records an Event against a pod.
+// Pods without a known UID (e.g. endpoints without a target pod) are skipped.
+func (c *Client) RecordPodEvent(pod PodInfo, eventType, reason, message string) {
+	if pod.UID == "" {
+		return
+	}
+	c.eventRecorder().Event(&corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		UID:        pod.UID,
+	}, eventType, reason, message)
+}
+
+// RecordWorkloadEvent records an Event against the workload owning a pod.
+// Workloads of kinds other than Deployment, StatefulSet, DaemonSet and
+// ReplicaSet are skipped.
+func (c *Client) RecordWorkloadEvent(namespace string, workload *domain.WorkloadRef, eventType, reason, message string) {
+	if workload == nil || workload.UID == "" {
+		return
+	}
+	apiVersion, ok := workloadAPIVersions[workload.Kind]
+	if !ok {
+		return
+	}
+	c.eventRecorder().Event(&corev1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       workload.Kind,
+		Namespace:  namespace,
+		Name:       workload.Name,
+		UID:        types.UID(workload.UID),
+	}, eventType, reason, message)
+}
+
+// Close flushes and stops the Event broadcaster, if it was started
+func (c *Client) Close() {
+	if c.eventBroadcaster != nil {
+		c.eventBroadcaster.Shutdown()
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// containerImage returns the image reference and digest of the container serving
+// the gRPC port. When no container declares the port, the first container is used.
+// The digest comes from the container status and is empty until the image is pulled.
+func containerImage(pod corev1.Pod, grpcPort int32) (image, digest string) {
+	if len(pod.Spec.Containers) == 0 {
+		return "", ""
+	}
+
+	container := pod.Spec.Containers[0]
+	for _, candidate := range pod.Spec.Containers {
+		if containerHasPort(candidate, grpcPort) {
+			container = candidate
+			break
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container.Name {
+			digest = imageDigest(status.ImageID)
+			break
+		}
+	}
+	return container.Image, digest
+}
+
+// containerHasPort reports whether a container declares the port
+func containerHasPort(container corev1.Container, port int32) bool {
+	for _, containerPort := range container.Ports {
+		if containerPort.ContainerPort == port {
+			return true
+		}
+	}
+	return false
+}
+
+// imageDigest extracts the "sha256:..." digest from a container status imageID,
+// which runtimes report as "docker-pullable://repo@sha256:...", "repo@sha256:..."
+// or a bare "sha256:..."
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return strings.TrimPrefix(imageID, "docker://")
+}
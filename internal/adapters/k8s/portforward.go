@@ -0,0 +1,102 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward is an active API server port-forward to a pod port.
+// It lets the gRPC client reach pods when protodiff runs outside the cluster
+// and pod IPs are not routable.
+type PortForward struct {
+	// LocalAddress is the local host:port that forwards to the pod
+	LocalAddress string
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// Close stops the port-forward and waits for it to shut down
+func (pf *PortForward) Close() {
+	close(pf.stopChan)
+	<-pf.doneChan
+}
+
+// PortForward opens a port-forward to the given pod port on a random local port.
+// The caller must Close the returned PortForward when done.
+func (c *Client) PortForward(ctx context.Context, namespace, podName string, port int32) (*PortForward, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward transport: %w", err)
+	}
+
+	url := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", port)},
+		stopChan,
+		readyChan,
+		io.Discard,
+		io.Discard,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward to %s/%s: %w", namespace, podName, err)
+	}
+
+	pf := &PortForward{
+		stopChan: stopChan,
+		doneChan: make(chan struct{}),
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(pf.doneChan)
+		errChan <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		return nil, fmt.Errorf("port-forward to %s/%s failed: %w", namespace, podName, err)
+	case <-ctx.Done():
+		pf.Close()
+		return nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		pf.Close()
+		return nil, fmt.Errorf("failed to get forwarded port for %s/%s: %v", namespace, podName, err)
+	}
+	pf.LocalAddress = fmt.Sprintf("127.0.0.1:%d", ports[0].Local)
+
+	return pf, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// grpcPortName is the conventional name of a gRPC port
+	grpcPortName = "grpc"
+	// grpcAppProtocol is the Service appProtocol value for gRPC ports
+	grpcAppProtocol = "grpc"
+)
+
+// portDetection is the outcome of gRPC port detection for a pod
+type portDetection struct {
+	port       int32
+	source     domain.PortSource
+	candidates []int32
+}
+
+// detectGRPCPort determines the gRPC port of a pod. Rules are applied in order:
+//  1. the protodiff.io/port annotation
+//  2. a container port named "grpc" or "grpc-*"
+//  3. a Service port with appProtocol "grpc" (or a gRPC name) targeting the pod
+//  4. otherwise the pod's TCP ports and 9090 become probe candidates, and the
+//     first candidate is used until probing finds a reflection endpoint
+func detectGRPCPort(pod corev1.Pod, overrides PodOverrides, services []corev1.Service) portDetection {
+	if overrides.Port != 0 {
+		return portDetection{port: overrides.Port, source: domain.PortSourceAnnotation}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if isGRPCPortName(port.Name) && isTCP(port.Protocol) {
+				return portDetection{port: port.ContainerPort, source: domain.PortSourcePortName}
+			}
+		}
+	}
+
+	for _, svc := range services {
+		for _, svcPort := range svc.Spec.Ports {
+			if !isTCP(svcPort.Protocol) {
+				continue
+			}
+			source := domain.PortSourceAppProtocol
+			if svcPort.AppProtocol == nil || !strings.EqualFold(*svcPort.AppProtocol, grpcAppProtocol) {
+				if !isGRPCPortName(svcPort.Name) {
+					continue
+				}
+				source = domain.PortSourcePortName
+			}
+			if port, ok := resolveTargetPort(pod, svcPort); ok {
+				return portDetection{port: port, source: source}
+			}
+		}
+	}
+
+	var candidates []int32
+	seen := make(map[int32]bool)
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if isTCP(port.Protocol) && !seen[port.ContainerPort] {
+				seen[port.ContainerPort] = true
+				candidates = append(candidates, port.ContainerPort)
+			}
+		}
+	}
+	if !seen[DefaultGRPCPort] {
+		candidates = append(candidates, DefaultGRPCPort)
+	}
+
+	return portDetection{
+		port:       candidates[0],
+		source:     domain.PortSourceDefault,
+		candidates: candidates,
+	}
+}
+
+// isGRPCPortName reports whether a port name follows the grpc / grpc-* convention
+func isGRPCPortName(name string) bool {
+	return name == grpcPortName || strings.HasPrefix(name, grpcPortName+"-")
+}
+
+// isTCP reports whether a port protocol is TCP (the default when unset)
+func isTCP(protocol corev1.Protocol) bool {
+	return protocol == "" || protocol == corev1.ProtocolTCP
+}
+
+// resolveTargetPort maps a Service port's targetPort to a container port of the pod
+func resolveTargetPort(pod corev1.Pod, svcPort corev1.ServicePort) (int32, bool) {
+	target := svcPort.TargetPort
+	if target.Type == intstr.Int {
+		if target.IntVal == 0 {
+			// targetPort defaults to the service port
+			return svcPort.Port, true
+		}
+		return target.IntVal, true
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == target.StrVal {
+				return port.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// serviceCache lists Services once per namespace during a discovery pass
+type serviceCache struct {
+	client      *Client
+	byNamespace map[string][]corev1.Service
+}
+
+// newServiceCache creates an empty cache bound to the client
+func newServiceCache(client *Client) *serviceCache {
+	return &serviceCache{
+		client:      client,
+		byNamespace: make(map[string][]corev1.Service),
+	}
+}
+
+// forPod returns the Services in the pod's namespace whose selector matches the pod.
+// Listing errors are logged and treated as no Services.
+func (sc *serviceCache) forPod(ctx context.Context, pod corev1.Pod) []corev1.Service {
+	services, ok := sc.byNamespace[pod.Namespace]
+	if !ok {
+		list, err := sc.client.clientset.CoreV1().Services(pod.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Printf("Warning: Failed to list services in %s for port detection: %v", pod.Namespace, err)
+		} else {
+			services = list.Items
+		}
+		sc.byNamespace[pod.Namespace] = services
+	}
+
+	var matching []corev1.Service
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, svc)
+		}
+	}
+	return matching
+}
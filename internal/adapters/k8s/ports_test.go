@@ -0,0 +1,56 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDetectGRPCPortServicePortSource(t *testing.T) {
+	grpc := grpcAppProtocol
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {Name: "rpc", ContainerPort: 9000}},
+	}}}}
+
+	tests := []struct {
+		name    string
+		svcPort corev1.ServicePort
+		want    domain.PortSource
+	}{
+		{
+			name:    "app protocol",
+			svcPort: corev1.ServicePort{Name: "api", Port: 80, TargetPort: intstr.FromString("rpc"), AppProtocol: &grpc},
+			want:    domain.PortSourceAppProtocol,
+		},
+		{
+			name:    "port name",
+			svcPort: corev1.ServicePort{Name: "grpc-api", Port: 80, TargetPort: intstr.FromString("rpc")},
+			want:    domain.PortSourcePortName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{tt.svcPort}}}
+			got := detectGRPCPort(pod, PodOverrides{}, []corev1.Service{svc})
+			if got.port != 9000 || got.source != tt.want {
+				t.Errorf("detectGRPCPort() = %d (%s), want 9000 (%s)", got.port, got.source, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SchemaBindingResource identifies the SchemaBinding custom resource
+var SchemaBindingResource = schema.GroupVersionResource{
+	Group:    "protodiff.io",
+	Version:  "v1alpha1",
+	Resource: "schemabindings",
+}
+
+const (
+	// ConditionSynced is the SchemaBinding condition reporting schema sync status
+	ConditionSynced = "Synced"
+
+	// Reasons for the Synced condition
+	ReasonInSync           = "InSync"
+	ReasonDriftDetected    = "DriftDetected"
+	ReasonValidationFailed = "ValidationFailed"
+	ReasonNoMatchingPods   = "NoMatchingPods"
+
+	// ComparisonModeMethods compares the methods of services present on both sides
+	ComparisonModeMethods = "methods"
+	// ComparisonModeStrict also treats missing and extra services as drift
+	ComparisonModeStrict = "strict"
+)
+
+// SchemaBindingSpec is the desired state of a SchemaBinding
+type SchemaBindingSpec struct {
+	Selector    metav1.LabelSelector `json:"selector"`
+	ServiceName string               `json:"serviceName,omitempty"`
+	Truth       struct {
+		Module string `json:"module"`
+	} `json:"truth"`
+	Comparison struct {
+		Mode           string   `json:"mode,omitempty"`
+		IgnoreServices []string `json:"ignoreServices,omitempty"`
+	} `json:"comparison,omitempty"`
+	Probe struct {
+		Port               int32  `json:"port,omitempty"`
+		TLS                bool   `json:"tls,omitempty"`
+		InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+		ServerName         string `json:"serverName,omitempty"`
+	} `json:"probe,omitempty"`
+}
+
+// SchemaBindingStatus is the observed sync/drift state of a SchemaBinding
+type SchemaBindingStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	PodCount           int                `json:"podCount"`
+	SyncCount          int                `json:"syncCount"`
+	MismatchCount      int                `json:"mismatchCount"`
+	UnknownCount       int                `json:"unknownCount"`
+	LastChecked        *metav1.Time       `json:"lastChecked,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SchemaBinding binds the pods matched by a selector to a BSR module
+type SchemaBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaBindingSpec   `json:"spec"`
+	Status SchemaBindingStatus `json:"status,omitempty"`
+}
+
+// Key returns the namespace/name identifier of the binding
+func (b *SchemaBinding) Key() string {
+	return b.Namespace + "/" + b.Name
+}
+
+// ListSchemaBindings lists SchemaBindings in the allowed namespaces.
+// It returns no bindings and no error when the CRD is not installed.
+func (c *Client) ListSchemaBindings(ctx context.Context) ([]SchemaBinding, error) {
+	namespaces := c.includeNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var bindings []SchemaBinding
+	for _, namespace := range namespaces {
+		list, err := c.dynamic.Resource(SchemaBindingResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to list schemabindings: %w", err)
+		}
+
+		for _, item := range list.Items {
+			if c.excludeNamespaces[item.GetNamespace()] {
+				continue
+			}
+			var binding SchemaBinding
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &binding); err != nil {
+				return nil, fmt.Errorf("failed to decode schemabinding %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+			}
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+// WatchSchemaBindings watches SchemaBindings in the allowed namespaces and calls
+// onChange for each binding whose spec has not been reconciled yet: bindings that
+// were just created or whose spec changed since their status was last written.
+// Status updates leave the generation unchanged and are not reported.
+// It returns once the watches are running; they stop when ctx is done.
+// Nothing is watched when the CRD is not installed.
+func (c *Client) WatchSchemaBindings(ctx context.Context, onChange func(SchemaBinding)) error {
+	namespaces := c.includeNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	_, err := c.dynamic.Resource(SchemaBindingResource).Namespace(namespaces[0]).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list schemabindings: %w", err)
+	}
+
+	handle := func(obj interface{}) {
+		item, ok := obj.(*unstructured.Unstructured)
+		if !ok || c.excludeNamespaces[item.GetNamespace()] {
+			return
+		}
+		var binding SchemaBinding
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &binding); err != nil {
+			log.Printf("Warning: Failed to decode schemabinding %s/%s: %v", item.GetNamespace(), item.GetName(), err)
+			return
+		}
+		if binding.Status.ObservedGeneration == binding.Generation {
+			return
+		}
+		onChange(binding)
+	}
+
+	for _, namespace := range namespaces {
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.dynamic, 0, namespace, nil)
+		informer := factory.ForResource(SchemaBindingResource).Informer()
+		if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    handle,
+			UpdateFunc: func(_, obj interface{}) { handle(obj) },
+		}); err != nil {
+			return fmt.Errorf("failed to watch schemabindings: %w", err)
+		}
+		factory.Start(ctx.Done())
+		factory.WaitForCacheSync(ctx.Done())
+	}
+	return nil
+}
+
+// DiscoverPodsForBinding finds the running pods selected by a SchemaBinding.
+// The binding's truth, comparison and probe settings become pod overrides;
+// protodiff.io annotations on the pod still take precedence.
+func (c *Client) DiscoverPodsForBinding(ctx context.Context, binding SchemaBinding) ([]PodInfo, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&binding.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector in schemabinding %s: %w", binding.Key(), err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("schemabinding %s has an empty selector", binding.Key())
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(binding.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for schemabinding %s: %w", binding.Key(), err)
+	}
+
+	serviceName := binding.Spec.ServiceName
+	if serviceName == "" {
+		serviceName = binding.Name
+	}
+
+	resolver := newDiscoveryResolver(c)
+	var podInfos []PodInfo
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		applyBindingDefaults(&pod, binding)
+
+		info := c.newPodInfo(ctx, pod, serviceName, resolver)
+		info.Binding = binding.Key()
+		info.StrictComparison = binding.Spec.Comparison.Mode == ComparisonModeStrict
+		podInfos = append(podInfos, info)
+	}
+	return podInfos, nil
+}
+
+// applyBindingDefaults fills in protodiff.io annotations from the binding
+// where the pod doesn't set them, so the usual override parsing applies
+func applyBindingDefaults(pod *corev1.Pod, binding SchemaBinding) {
+	defaults := map[string]string{
+		AnnotationModule: binding.Spec.Truth.Module,
+	}
+	if binding.Spec.Probe.Port != 0 {
+		defaults[AnnotationPort] = fmt.Sprint(binding.Spec.Probe.Port)
+	}
+	if binding.Spec.Probe.TLS {
+		defaults[AnnotationTLS] = "true"
+		if binding.Spec.Probe.InsecureSkipVerify {
+			defaults[AnnotationTLS] = tlsInsecureValue
+		}
+	}
+	if binding.Spec.Probe.ServerName != "" {
+		defaults[AnnotationTLSServerName] = binding.Spec.Probe.ServerName
+	}
+	if len(binding.Spec.Comparison.IgnoreServices) > 0 {
+		defaults[AnnotationIgnoreServices] = strings.Join(binding.Spec.Comparison.IgnoreServices, ",")
+	}
+
+	annotations := make(map[string]string, len(pod.Annotations)+len(defaults))
+	for key, value := range defaults {
+		annotations[key] = value
+	}
+	for key, value := range pod.Annotations {
+		annotations[key] = value
+	}
+	pod.Annotations = annotations
+}
+
+// UpdateSchemaBindingStatus writes the status subresource of a SchemaBinding.
+// The Synced condition keeps its lastTransitionTime unless its status changes.
+func (c *Client) UpdateSchemaBindingStatus(ctx context.Context, binding SchemaBinding, status SchemaBindingStatus, condition metav1.Condition) error {
+	current, err := c.dynamic.Resource(SchemaBindingResource).Namespace(binding.Namespace).Get(ctx, binding.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get schemabinding %s: %w", binding.Key(), err)
+	}
+
+	var latest SchemaBinding
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current.Object, &latest); err != nil {
+		return fmt.Errorf("failed to decode schemabinding %s: %w", binding.Key(), err)
+	}
+
+	status.ObservedGeneration = latest.Generation
+	status.Conditions = latest.Status.Conditions
+	condition.ObservedGeneration = latest.Generation
+	meta.SetStatusCondition(&status.Conditions, condition)
+	now := metav1.NewTime(time.Now())
+	status.LastChecked = &now
+
+	statusObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("failed to encode schemabinding status: %w", err)
+	}
+	if err := unstructured.SetNestedField(current.Object, statusObj, "status"); err != nil {
+		return fmt.Errorf("failed to set schemabinding status: %w", err)
+	}
+
+	_, err = c.dynamic.Resource(SchemaBindingResource).Namespace(binding.Namespace).UpdateStatus(ctx, current, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update schemabinding %s status: %w", binding.Key(), err)
+	}
+	return nil
+}
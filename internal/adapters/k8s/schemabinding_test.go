@@ -0,0 +1,98 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestWatchSchemaBindings(t *testing.T) {
+	dynamic := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		SchemaBindingResource: "SchemaBindingList",
+	})
+	client := &Client{name: "test", dynamic: dynamic}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan SchemaBinding, 10)
+	if err := client.WatchSchemaBindings(ctx, func(binding SchemaBinding) { changes <- binding }); err != nil {
+		t.Fatalf("WatchSchemaBindings() error = %v", err)
+	}
+
+	bindings := dynamic.Resource(SchemaBindingResource).Namespace("prod")
+	binding := schemaBindingObject("users", 1, 0)
+	if _, err := bindings.Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	expectBindingChange(t, changes, "prod/users", 1)
+
+	// A status write for the current generation is not a change
+	if _, err := bindings.Update(ctx, schemaBindingObject("users", 1, 1), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// A spec change bumps the generation past the observed one
+	if _, err := bindings.Update(ctx, schemaBindingObject("users", 2, 1), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	expectBindingChange(t, changes, "prod/users", 2)
+
+	select {
+	case binding := <-changes:
+		t.Errorf("unexpected change of %s (generation %d)", binding.Key(), binding.Generation)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// schemaBindingObject builds a SchemaBinding in the prod namespace
+func schemaBindingObject(name string, generation, observedGeneration int64) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": SchemaBindingResource.GroupVersion().String(),
+		"kind":       "SchemaBinding",
+		"metadata": map[string]interface{}{
+			"name":       name,
+			"namespace":  "prod",
+			"generation": generation,
+		},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+			"truth":    map[string]interface{}{"module": "buf.build/acme/" + name},
+		},
+	}}
+	if observedGeneration != 0 {
+		object.Object["status"] = map[string]interface{}{"observedGeneration": observedGeneration}
+	}
+	return object
+}
+
+// expectBindingChange waits for the next reported change and checks it
+func expectBindingChange(t *testing.T, changes <-chan SchemaBinding, key string, generation int64) {
+	t.Helper()
+	select {
+	case binding := <-changes:
+		if binding.Key() != key || binding.Generation != generation {
+			t.Errorf("change of %s (generation %d), want %s (generation %d)", binding.Key(), binding.Generation, key, generation)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no change reported for %s (generation %d)", key, generation)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DiscoverServiceEndpoints discovers gRPC backends the way clients reach them:
+// starting from Kubernetes Services, it resolves the ready addresses of their
+// EndpointSlices on the Service's gRPC port. Terminating and non-ready
+// endpoints are skipped.
+//
+// With service names (from the ConfigMap), Services are matched by their
+// service name label or, failing that, by their own name. Without service
+// names, Services matching the opt-in selector are used.
+func (c *Client) DiscoverServiceEndpoints(ctx context.Context, serviceNames []string) ([]PodInfo, error) {
+	wanted := make(map[string]bool, len(serviceNames))
+	for _, name := range serviceNames {
+		wanted[name] = true
+	}
+
+	selector := labels.Everything()
+	if len(wanted) == 0 {
+		selector = c.optInSelector
+	}
+
+	services, err := c.listServices(ctx, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	workloads := newWorkloadResolver(c)
+	var podInfos []PodInfo
+	for _, svc := range services {
+		serviceName := svc.Labels[c.serviceNameLabel]
+		if serviceName == "" {
+			serviceName = svc.Name
+		}
+		if len(wanted) > 0 && !wanted[serviceName] {
+			continue
+		}
+
+		endpoints, err := c.serviceEndpoints(ctx, svc, serviceName, workloads)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve endpoints for service %s/%s: %w", svc.Namespace, svc.Name, err)
+		}
+		podInfos = append(podInfos, endpoints...)
+	}
+
+	return podInfos, nil
+}
+
+// listServices lists Services matching the selector in the allowed namespaces
+func (c *Client) listServices(ctx context.Context, selector labels.Selector) ([]corev1.Service, error) {
+	namespaces := c.includeNamespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var services []corev1.Service
+	for _, namespace := range namespaces {
+		list, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, svc := range list.Items {
+			if c.excludeNamespaces[svc.Namespace] {
+				continue
+			}
+			services = append(services, svc)
+		}
+	}
+	return services, nil
+}
+
+// serviceEndpoints resolves the ready endpoints of a Service on its gRPC port.
+// Annotations on an endpoint's pod take precedence over the Service's, except
+// for the port.
+func (c *Client) serviceEndpoints(ctx context.Context, svc corev1.Service, serviceName string, workloads *workloadResolver) ([]PodInfo, error) {
+	overrides := parsePodOverrides(svc.ObjectMeta)
+	svcPort, source, ok := selectServicePort(svc, overrides)
+	if !ok {
+		return nil, nil
+	}
+
+	slices, err := c.clientset.DiscoveryV1().EndpointSlices(svc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, svc.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var podInfos []PodInfo
+	for _, slice := range slices.Items {
+		port, ok := endpointSlicePort(slice, svcPort)
+		if !ok {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if !endpointReady(endpoint) || len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			info := PodInfo{
+				Name:        endpoint.Addresses[0],
+				Namespace:   svc.Namespace,
+				ServiceName: serviceName,
+				IP:          endpoint.Addresses[0],
+				GRPCPort:    port,
+				PortSource:  source,
+				KubeService: svc.Name,
+				Overrides:   overrides,
+			}
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				info.Name = endpoint.TargetRef.Name
+				info.UID = endpoint.TargetRef.UID
+				if pod := c.endpointPod(ctx, svc.Namespace, info.Name); pod != nil {
+					info.Overrides = mergePodOverrides(overrides, pod.ObjectMeta)
+					info.Workload = workloads.resolve(ctx, *pod)
+					info.Image, info.ImageDigest = containerImage(*pod, port)
+				}
+			}
+
+			podInfos = append(podInfos, info)
+		}
+	}
+	return podInfos, nil
+}
+
+// endpointPod fetches an endpoint's backing pod to resolve its workload and image
+func (c *Client) endpointPod(ctx context.Context, namespace, podName string) *corev1.Pod {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Warning: Failed to get pod %s/%s for endpoint details: %v", namespace, podName, err)
+		return nil
+	}
+	return pod
+}
+
+// selectServicePort picks the gRPC port of a Service: the port matching the
+// protodiff.io/port annotation, a port with appProtocol "grpc" or a grpc/grpc-*
+// name, or the only TCP port of the Service.
+func selectServicePort(svc corev1.Service, overrides PodOverrides) (corev1.ServicePort, domain.PortSource, bool) {
+	var tcpPorts []corev1.ServicePort
+	for _, svcPort := range svc.Spec.Ports {
+		if !isTCP(svcPort.Protocol) {
+			continue
+		}
+		if overrides.Port != 0 && (svcPort.Port == overrides.Port || svcPort.TargetPort.IntVal == overrides.Port) {
+			return svcPort, domain.PortSourceAnnotation, true
+		}
+		tcpPorts = append(tcpPorts, svcPort)
+	}
+
+	for _, svcPort := range tcpPorts {
+		if svcPort.AppProtocol != nil && strings.EqualFold(*svcPort.AppProtocol, grpcAppProtocol) {
+			return svcPort, domain.PortSourceAppProtocol, true
+		}
+	}
+	for _, svcPort := range tcpPorts {
+		if isGRPCPortName(svcPort.Name) {
+			return svcPort, domain.PortSourcePortName, true
+		}
+	}
+
+	if len(tcpPorts) == 1 {
+		return tcpPorts[0], domain.PortSourceDefault, true
+	}
+	return corev1.ServicePort{}, "", false
+}
+
+// endpointSlicePort finds the endpoint port number backing the Service port.
+// EndpointSlice ports carry the Service port's name and the resolved target port.
+func endpointSlicePort(slice discoveryv1.EndpointSlice, svcPort corev1.ServicePort) (int32, bool) {
+	for _, port := range slice.Ports {
+		if port.Port == nil {
+			continue
+		}
+		name := ""
+		if port.Name != nil {
+			name = *port.Name
+		}
+		if name != svcPort.Name {
+			continue
+		}
+		return *port.Port, true
+	}
+	return 0, false
+}
+
+// endpointReady reports whether an endpoint is ready and not terminating.
+// A nil condition is interpreted as ready / not terminating, per the API contract.
+func endpointReady(endpoint discoveryv1.Endpoint) bool {
+	if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+		return false
+	}
+	if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// deploymentRevisionAnnotation holds the Deployment revision number on a ReplicaSet
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// controllerRevisionHashLabel identifies the StatefulSet/DaemonSet revision of a pod
+	controllerRevisionHashLabel = "controller-revision-hash"
+)
+
+// workloadResolver walks owner references from pods to their workloads.
+// ReplicaSets are fetched once per discovery pass.
+type workloadResolver struct {
+	client      *Client
+	replicaSets map[string]*appsv1.ReplicaSet
+}
+
+// newWorkloadResolver creates an empty resolver bound to the client
+func newWorkloadResolver(client *Client) *workloadResolver {
+	return &workloadResolver{
+		client:      client,
+		replicaSets: make(map[string]*appsv1.ReplicaSet),
+	}
+}
+
+// resolve returns the workload owning the pod, or nil for bare pods.
+// Pods owned by a ReplicaSet are attributed to its Deployment when there is one.
+func (wr *workloadResolver) resolve(ctx context.Context, pod corev1.Pod) *domain.WorkloadRef {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return nil
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		ref := &domain.WorkloadRef{Kind: owner.Kind, Name: owner.Name, Revision: owner.Name, UID: string(owner.UID)}
+		rs := wr.replicaSet(ctx, pod.Namespace, owner.Name)
+		if rs == nil {
+			return ref
+		}
+		if number, err := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64); err == nil {
+			ref.RevisionNumber = number
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == "Deployment" {
+			ref.Kind = rsOwner.Kind
+			ref.Name = rsOwner.Name
+			ref.UID = string(rsOwner.UID)
+		}
+		return ref
+	case "StatefulSet", "DaemonSet":
+		return &domain.WorkloadRef{
+			Kind:     owner.Kind,
+			Name:     owner.Name,
+			Revision: pod.Labels[controllerRevisionHashLabel],
+			UID:      string(owner.UID),
+		}
+	default:
+		return &domain.WorkloadRef{Kind: owner.Kind, Name: owner.Name, UID: string(owner.UID)}
+	}
+}
+
+// replicaSet fetches a ReplicaSet, caching both hits and misses
+func (wr *workloadResolver) replicaSet(ctx context.Context, namespace, name string) *appsv1.ReplicaSet {
+	key := namespace + "/" + name
+	if rs, ok := wr.replicaSets[key]; ok {
+		return rs
+	}
+
+	rs, err := wr.client.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Warning: Failed to get ReplicaSet %s: %v", key, err)
+		rs = nil
+	}
+	wr.replicaSets[key] = rs
+	return rs
+}
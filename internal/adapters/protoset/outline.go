@@ -0,0 +1,184 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protoset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Outline lists the services of a serialized FileDescriptorSet and the
+// messages and enums they use, as declarations to compare side by side.
+// Only the named services are included, or all services if names is nil.
+// Well-known types are left out. Declarations are sorted by kind and name.
+func Outline(data []byte, names []string) ([]domain.SchemaDecl, error) {
+	set, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	files, err := desc.CreateFileDescriptorsFromSet(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link FileDescriptorSet: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	o := &outliner{
+		messages: make(map[string]*desc.MessageDescriptor),
+		enums:    make(map[string]*desc.EnumDescriptor),
+	}
+	var services []*desc.ServiceDescriptor
+	for _, file := range set.GetFile() {
+		for _, svc := range files[file.GetName()].GetServices() {
+			if names == nil || wanted[svc.GetFullyQualifiedName()] {
+				services = append(services, svc)
+			}
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].GetFullyQualifiedName() < services[j].GetFullyQualifiedName()
+	})
+
+	var decls []domain.SchemaDecl
+	for _, svc := range services {
+		decl := domain.SchemaDecl{Kind: domain.DeclService, Name: svc.GetFullyQualifiedName()}
+		for _, method := range svc.GetMethods() {
+			o.addMessage(method.GetInputType())
+			o.addMessage(method.GetOutputType())
+			decl.Members = append(decl.Members, domain.SchemaMember{
+				Name:        method.GetName(),
+				Declaration: methodDeclaration(method),
+			})
+		}
+		decls = append(decls, decl)
+	}
+	return append(decls, o.decls()...), nil
+}
+
+// outliner collects the messages and enums reachable from services
+type outliner struct {
+	messages map[string]*desc.MessageDescriptor
+	enums    map[string]*desc.EnumDescriptor
+}
+
+// addMessage collects a message and the types of its fields
+func (o *outliner) addMessage(msg *desc.MessageDescriptor) {
+	if msg == nil || wellKnown(msg.GetFile()) || o.messages[msg.GetFullyQualifiedName()] != nil {
+		return
+	}
+	// Map entries are rendered as map<K, V> fields, only their value type matters
+	if !msg.IsMapEntry() {
+		o.messages[msg.GetFullyQualifiedName()] = msg
+	}
+	for _, field := range msg.GetFields() {
+		o.addMessage(field.GetMessageType())
+		if enum := field.GetEnumType(); enum != nil && !wellKnown(enum.GetFile()) {
+			o.enums[enum.GetFullyQualifiedName()] = enum
+		}
+	}
+}
+
+// decls returns the collected messages and enums as sorted declarations
+func (o *outliner) decls() []domain.SchemaDecl {
+	var decls []domain.SchemaDecl
+	for name, msg := range o.messages {
+		decl := domain.SchemaDecl{Kind: domain.DeclMessage, Name: name}
+		for _, field := range msg.GetFields() {
+			decl.Members = append(decl.Members, domain.SchemaMember{
+				Name:        field.GetName(),
+				Declaration: fieldDeclaration(field),
+			})
+		}
+		decls = append(decls, decl)
+	}
+	for name, enum := range o.enums {
+		decl := domain.SchemaDecl{Kind: domain.DeclEnum, Name: name}
+		for _, value := range enum.GetValues() {
+			decl.Members = append(decl.Members, domain.SchemaMember{
+				Name:        value.GetName(),
+				Declaration: fmt.Sprintf("%s = %d", value.GetName(), value.GetNumber()),
+			})
+		}
+		decls = append(decls, decl)
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		if decls[i].Kind != decls[j].Kind {
+			return decls[i].Kind == domain.DeclMessage
+		}
+		return decls[i].Name < decls[j].Name
+	})
+	return decls
+}
+
+// methodDeclaration renders a method, e.g. "rpc Watch(acme.WatchRequest) returns (stream acme.Event)"
+func methodDeclaration(method *desc.MethodDescriptor) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rpc %s(", method.GetName())
+	if method.IsClientStreaming() {
+		b.WriteString("stream ")
+	}
+	fmt.Fprintf(&b, "%s) returns (", method.GetInputType().GetFullyQualifiedName())
+	if method.IsServerStreaming() {
+		b.WriteString("stream ")
+	}
+	fmt.Fprintf(&b, "%s)", method.GetOutputType().GetFullyQualifiedName())
+	return b.String()
+}
+
+// fieldDeclaration renders a field, e.g. "repeated string tags = 2"
+func fieldDeclaration(field *desc.FieldDescriptor) string {
+	var b strings.Builder
+	switch {
+	case field.IsMap():
+		fmt.Fprintf(&b, "map<%s, %s>", fieldType(field.GetMapKeyType()), fieldType(field.GetMapValueType()))
+	case field.IsRepeated():
+		b.WriteString("repeated " + fieldType(field))
+	case field.IsRequired():
+		b.WriteString("required " + fieldType(field))
+	case field.IsProto3Optional() || (!field.GetFile().IsProto3() && field.GetOneOf() == nil):
+		b.WriteString("optional " + fieldType(field))
+	default:
+		b.WriteString(fieldType(field))
+	}
+	fmt.Fprintf(&b, " %s = %d", field.GetName(), field.GetNumber())
+	if oneof := field.GetOneOf(); oneof != nil && !field.IsProto3Optional() {
+		fmt.Fprintf(&b, " (oneof %s)", oneof.GetName())
+	}
+	return b.String()
+}
+
+// fieldType returns the fully qualified message or enum name of a field, or its scalar type
+func fieldType(field *desc.FieldDescriptor) string {
+	if msg := field.GetMessageType(); msg != nil {
+		return msg.GetFullyQualifiedName()
+	}
+	if enum := field.GetEnumType(); enum != nil {
+		return enum.GetFullyQualifiedName()
+	}
+	return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+}
+
+// wellKnown reports whether a file holds well-known types
+func wellKnown(file *desc.FileDescriptor) bool {
+	return strings.HasPrefix(file.GetName(), wellKnownPrefix)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protoset
+
+import (
+	"testing"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// outlineProtos exercises the field and method forms rendered by Outline
+var outlineProtos = map[string]string{
+	"acme/events.proto": `syntax = "proto3";
+package acme;
+
+import "google/protobuf/timestamp.proto";
+
+enum Level {
+  LEVEL_UNSPECIFIED = 0;
+  LEVEL_ERROR = 2;
+}
+
+message Label {
+  string value = 1;
+}
+
+message WatchRequest {
+  repeated string topics = 1;
+  optional int32 limit = 2;
+  map<string, Label> labels = 3;
+  oneof cursor {
+    string after = 4;
+    int64 offset = 5;
+  }
+}
+
+message Event {
+  Level level = 1;
+  google.protobuf.Timestamp at = 2;
+}
+
+message Unused {
+  string name = 1;
+}
+
+service EventService {
+  rpc Watch(WatchRequest) returns (stream Event);
+}
+
+service AdminService {
+  rpc Purge(Unused) returns (Unused);
+}
+`,
+}
+
+// outlineSnapshot serializes the outline test protos
+func outlineSnapshot(t *testing.T) []byte {
+	t.Helper()
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(outlineProtos)}
+	files, err := parser.ParseFiles("acme/events.proto")
+	if err != nil {
+		t.Fatalf("failed to parse test protos: %v", err)
+	}
+	data, err := FromFiles(files)
+	if err != nil {
+		t.Fatalf("FromFiles() error = %v", err)
+	}
+	return data
+}
+
+// declarations indexes the member declarations of decls by declaration and member name
+func declarations(decls []domain.SchemaDecl) map[string]map[string]string {
+	index := make(map[string]map[string]string)
+	for _, decl := range decls {
+		members := make(map[string]string)
+		for _, member := range decl.Members {
+			members[member.Name] = member.Declaration
+		}
+		index[decl.Kind+" "+decl.Name] = members
+	}
+	return index
+}
+
+func TestOutline(t *testing.T) {
+	decls, err := Outline(outlineSnapshot(t), []string{"acme.EventService"})
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+
+	var names []string
+	for _, decl := range decls {
+		names = append(names, decl.Kind+" "+decl.Name)
+	}
+	// Services first, then the messages and enums they use; well-known types
+	// and the messages of other services are left out
+	want := []string{"service acme.EventService", "message acme.Event", "message acme.Label", "message acme.WatchRequest", "enum acme.Level"}
+	if len(names) != len(want) {
+		t.Fatalf("declarations = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("declarations = %v, want %v", names, want)
+		}
+	}
+
+	index := declarations(decls)
+	tests := []struct {
+		decl   string
+		member string
+		want   string
+	}{
+		{"service acme.EventService", "Watch", "rpc Watch(acme.WatchRequest) returns (stream acme.Event)"},
+		{"message acme.WatchRequest", "topics", "repeated string topics = 1"},
+		{"message acme.WatchRequest", "limit", "optional int32 limit = 2"},
+		{"message acme.WatchRequest", "labels", "map<string, acme.Label> labels = 3"},
+		{"message acme.WatchRequest", "after", "string after = 4 (oneof cursor)"},
+		{"message acme.Event", "level", "acme.Level level = 1"},
+		{"message acme.Event", "at", "google.protobuf.Timestamp at = 2"},
+		{"enum acme.Level", "LEVEL_ERROR", "LEVEL_ERROR = 2"},
+	}
+	for _, tt := range tests {
+		if got := index[tt.decl][tt.member]; got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.decl, tt.member, got, tt.want)
+		}
+	}
+}
+
+func TestOutlineAllServices(t *testing.T) {
+	decls, err := Outline(outlineSnapshot(t), nil)
+	if err != nil {
+		t.Fatalf("Outline() error = %v", err)
+	}
+	index := declarations(decls)
+	for _, want := range []string{"service acme.AdminService", "service acme.EventService", "message acme.Unused"} {
+		if _, ok := index[want]; !ok {
+			t.Errorf("Outline() has no %s", want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protoset serializes and renders protobuf FileDescriptorSets.
+//
+// Schemas fetched from live pods and from BSR are kept as serialized
+// FileDescriptorSets (schema snapshots) so users can download exactly what was
+// compared. This package builds those sets from parsed file descriptors,
+// renders them as binary, JSON or .proto source, and outlines their services,
+// messages and enums for side-by-side comparison.
+//
+// Example usage:
+//
+//	data, err := protoset.FromFiles(files)
+//	source, contentType, err := protoset.Render(data, protoset.FormatProto)
+package protoset
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoprint"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Download formats of a schema snapshot
+const (
+	// FormatBinary is the serialized FileDescriptorSet, as produced by `protoc -o` or `buf build`
+	FormatBinary = "binpb"
+	// FormatJSON is the FileDescriptorSet in the protobuf JSON mapping
+	FormatJSON = "json"
+	// FormatProto is the schema rendered back to .proto source
+	FormatProto = "proto"
+)
+
+// wellKnownPrefix is the path prefix of the well-known types, which are left
+// out of rendered .proto source
+const wellKnownPrefix = "google/protobuf/"
+
+// FromFiles serializes the files and all their transitive dependencies into a
+// FileDescriptorSet. Dependencies precede the files importing them and the
+// output is deterministic, so equal schemas serialize to equal bytes.
+func FromFiles(files []*desc.FileDescriptor) ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	var add func(fd *desc.FileDescriptor)
+	add = func(fd *desc.FileDescriptor) {
+		if seen[fd.GetName()] {
+			return
+		}
+		seen[fd.GetName()] = true
+		for _, dep := range fd.GetDependencies() {
+			add(dep)
+		}
+		set.File = append(set.File, fd.AsFileDescriptorProto())
+	}
+	for _, fd := range files {
+		add(fd)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal FileDescriptorSet: %w", err)
+	}
+	return data, nil
+}
+
+// Render converts a serialized FileDescriptorSet to the given format and
+// returns it with its content type
+func Render(data []byte, format string) ([]byte, string, error) {
+	switch format {
+	case FormatBinary:
+		return data, "application/octet-stream", nil
+	case FormatJSON:
+		set, err := unmarshal(data)
+		if err != nil {
+			return nil, "", err
+		}
+		out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(set)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode FileDescriptorSet as JSON: %w", err)
+		}
+		return out, "application/json", nil
+	case FormatProto:
+		out, err := renderProto(data)
+		if err != nil {
+			return nil, "", err
+		}
+		return out, "text/plain; charset=utf-8", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q (expected %s, %s or %s)", format, FormatBinary, FormatJSON, FormatProto)
+	}
+}
+
+// renderProto prints every file of the set except the well-known types as
+// .proto source, separated by a comment naming the file
+func renderProto(data []byte) ([]byte, error) {
+	set, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	files, err := desc.CreateFileDescriptorsFromSet(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link FileDescriptorSet: %w", err)
+	}
+
+	var out bytes.Buffer
+	printer := &protoprint.Printer{}
+	for _, file := range set.GetFile() {
+		if strings.HasPrefix(file.GetName(), wellKnownPrefix) {
+			continue
+		}
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "// ===== %s =====\n\n", file.GetName())
+		if err := printer.PrintProtoFile(files[file.GetName()], &out); err != nil {
+			return nil, fmt.Errorf("failed to print %s: %w", file.GetName(), err)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// unmarshal decodes a serialized FileDescriptorSet
+func unmarshal(data []byte) (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to decode FileDescriptorSet: %w", err)
+	}
+	return set, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protoset
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+)
+
+// testProtos are the sources of a small schema importing a local file and a well-known type
+var testProtos = map[string]string{
+	"acme/common.proto": `syntax = "proto3";
+package acme;
+
+message Page {
+  int32 size = 1;
+}
+`,
+	"acme/users.proto": `syntax = "proto3";
+package acme;
+
+import "acme/common.proto";
+import "google/protobuf/timestamp.proto";
+
+message ListUsersRequest {
+  Page page = 1;
+  google.protobuf.Timestamp since = 2;
+}
+
+message ListUsersResponse {
+  repeated string names = 1;
+}
+
+service UserService {
+  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse);
+}
+`,
+}
+
+// parseTestFiles parses acme/users.proto with its imports
+func parseTestFiles(t *testing.T) []*desc.FileDescriptor {
+	t.Helper()
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(testProtos)}
+	files, err := parser.ParseFiles("acme/users.proto")
+	if err != nil {
+		t.Fatalf("failed to parse test protos: %v", err)
+	}
+	return files
+}
+
+func TestFromFiles(t *testing.T) {
+	data, err := FromFiles(parseTestFiles(t))
+	if err != nil {
+		t.Fatalf("FromFiles() error = %v", err)
+	}
+	set, err := unmarshal(data)
+	if err != nil {
+		t.Fatalf("unmarshal() error = %v", err)
+	}
+
+	var names []string
+	for _, file := range set.GetFile() {
+		names = append(names, file.GetName())
+	}
+	// Dependencies precede the files importing them
+	want := "acme/common.proto,google/protobuf/timestamp.proto,acme/users.proto"
+	if strings.Join(names, ",") != want {
+		t.Errorf("files = %v, want %s", names, want)
+	}
+
+	again, err := FromFiles(parseTestFiles(t))
+	if err != nil {
+		t.Fatalf("FromFiles() error = %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Error("FromFiles() is not deterministic for equal schemas")
+	}
+}
+
+func TestRender(t *testing.T) {
+	data, err := FromFiles(parseTestFiles(t))
+	if err != nil {
+		t.Fatalf("FromFiles() error = %v", err)
+	}
+
+	tests := []struct {
+		format          string
+		wantContentType string
+		check           func(t *testing.T, out []byte)
+	}{
+		{FormatBinary, "application/octet-stream", func(t *testing.T, out []byte) {
+			if !bytes.Equal(out, data) {
+				t.Error("binary rendering differs from the snapshot")
+			}
+		}},
+		{FormatJSON, "application/json", func(t *testing.T, out []byte) {
+			var set struct {
+				File []struct {
+					Name string `json:"name"`
+				} `json:"file"`
+			}
+			if err := json.Unmarshal(out, &set); err != nil {
+				t.Fatalf("invalid JSON %s: %v", out, err)
+			}
+			if len(set.File) != 3 || set.File[2].Name != "acme/users.proto" {
+				t.Errorf("JSON files = %+v, want the 3 files ending with acme/users.proto", set.File)
+			}
+		}},
+		{FormatProto, "text/plain; charset=utf-8", func(t *testing.T, out []byte) {
+			source := string(out)
+			for _, want := range []string{"// ===== acme/common.proto =====", "// ===== acme/users.proto =====", "service UserService", "rpc ListUsers"} {
+				if !strings.Contains(source, want) {
+					t.Errorf(".proto source does not contain %q:\n%s", want, source)
+				}
+			}
+			if strings.Contains(source, "===== google/protobuf/timestamp.proto") {
+				t.Errorf(".proto source includes the well-known types:\n%s", source)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, contentType, err := Render(data, tt.format)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("content type = %q, want %q", contentType, tt.wantContentType)
+			}
+			tt.check(t, out)
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	data, err := FromFiles(parseTestFiles(t))
+	if err != nil {
+		t.Fatalf("FromFiles() error = %v", err)
+	}
+	if _, _, err := Render(data, "yaml"); err == nil {
+		t.Error("Render() with an unsupported format succeeded, want an error")
+	}
+	for _, format := range []string{FormatJSON, FormatProto} {
+		if _, _, err := Render([]byte("not a descriptor set"), format); err == nil {
+			t.Errorf("Render(%s) of invalid data succeeded, want an error", format)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// APIPrefix is the path prefix of the versioned JSON API
+const APIPrefix = "/api/v1"
+
+// Pagination limits of list endpoints
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// Page is the envelope of paginated list responses
+type Page struct {
+	Items interface{} `json:"items"`
+	// Total is the number of items matching the filters, across all pages
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// APIStatistics is the response of the statistics endpoint
+type APIStatistics struct {
+	Total    int `json:"total"`
+	Sync     int `json:"sync"`
+	Mismatch int `json:"mismatch"`
+	Unknown  int `json:"unknown"`
+	Services int `json:"services"`
+	// DriftedServices counts services with at least one drifted pod
+	DriftedServices int      `json:"drifted_services"`
+	Clusters        []string `json:"clusters"`
+	// LastChecked is the most recent validation of any pod
+	LastChecked time.Time `json:"last_checked"`
+}
+
+// apiError is the body of error responses
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// resultFilter selects results by the list query parameters. Empty fields match everything.
+type resultFilter struct {
+	cluster   string
+	namespace string
+	service   string
+	module    string
+	status    domain.DiffStatus
+	// search is a lowercase free-text search term
+	search string
+}
+
+// registerAPI registers the JSON API handlers
+func (s *Server) registerAPI(mux *http.ServeMux) {
+	mux.HandleFunc(APIPrefix+"/results", s.apiGET(s.handleAPIResults))
+	mux.HandleFunc(APIPrefix+"/results/", s.apiGET(s.handleAPIResult))
+	mux.HandleFunc(APIPrefix+"/services", s.apiGET(s.handleAPIServices))
+	mux.HandleFunc(APIPrefix+"/stats", s.apiGET(s.handleAPIStats))
+	mux.HandleFunc(APIPrefix+"/scans", s.handleAPIScans)
+	mux.HandleFunc(APIPrefix+"/scans/", s.apiGET(s.handleAPIScan))
+	mux.HandleFunc(APIPrefix+"/openapi.json", s.apiGET(s.handleOpenAPI))
+}
+
+// apiGET restricts a handler to GET requests
+func (s *Server) apiGET(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeAPIError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleAPIResults lists scan results, filtered and paginated:
+//
+//	GET /api/v1/results?cluster=&namespace=&service=&status=&limit=&offset=
+func (s *Server) handleAPIResults(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseResultFilter(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	var results []*domain.ScanResult
+	for _, result := range s.store.GetAll() {
+		if filter.matches(result) {
+			results = append(results, result)
+		}
+	}
+	sortResults(results)
+
+	start, end := pageBounds(len(results), limit, offset)
+	writeJSON(w, http.StatusOK, Page{
+		Items:  append([]*domain.ScanResult{}, results[start:end]...),
+		Total:  len(results),
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// handleAPIResult returns the result of a single pod:
+//
+//	GET /api/v1/results/{cluster}/{namespace}/{pod}?kube_service=
+//
+// The cluster name may itself contain slashes; namespace and pod names cannot.
+// kube_service selects the result of a pod reached through that Service in
+// service discovery mode.
+func (s *Server) handleAPIResult(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, APIPrefix+"/results/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		writeAPIError(w, http.StatusNotFound, "expected %s/results/{cluster}/{namespace}/{pod}", APIPrefix)
+		return
+	}
+	podName := parts[len(parts)-1]
+	namespace := parts[len(parts)-2]
+	clusterName := strings.Join(parts[:len(parts)-2], "/")
+
+	result, ok := s.store.Get(domain.ResultKey{
+		ClusterName: clusterName,
+		Namespace:   namespace,
+		PodName:     podName,
+		KubeService: r.URL.Query().Get("kube_service"),
+	})
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "no result for pod %s/%s in cluster %q", namespace, podName, clusterName)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleAPIServices lists services with their aggregated status, paginated.
+// A service matches the namespace filter if any of its pods runs there:
+//
+//	GET /api/v1/services?cluster=&namespace=&service=&status=&limit=&offset=
+func (s *Server) handleAPIServices(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseResultFilter(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	// Aggregate all pods before filtering by status, so a service's status
+	// reflects every pod and not only those in the requested namespace
+	var services []domain.ServiceSummary
+	for _, service := range domain.AggregateServices(s.store.GetAll()) {
+		if filter.matchesService(service) {
+			services = append(services, service)
+		}
+	}
+
+	start, end := pageBounds(len(services), limit, offset)
+	writeJSON(w, http.StatusOK, Page{
+		Items:  append([]domain.ServiceSummary{}, services[start:end]...),
+		Total:  len(services),
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// handleAPIStats returns result counts by status
+func (s *Server) handleAPIStats(w http.ResponseWriter, r *http.Request) {
+	results := s.store.GetAll()
+	stats := calculateStatistics(results)
+	services := domain.AggregateServices(results)
+
+	response := APIStatistics{
+		Total:    stats.TotalCount,
+		Sync:     stats.SyncCount,
+		Mismatch: stats.MismatchCount,
+		Unknown:  stats.UnknownCount,
+		Services: len(services),
+		Clusters: []string{},
+	}
+	for _, service := range services {
+		if service.Status == domain.StatusMismatch {
+			response.DriftedServices++
+		}
+	}
+	for _, group := range groupByCluster(results) {
+		response.Clusters = append(response.Clusters, group.Name)
+	}
+	for _, result := range results {
+		if result.LastChecked.After(response.LastChecked) {
+			response.LastChecked = result.LastChecked
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleOpenAPI serves the OpenAPI document describing the API
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(openAPIDocument); err != nil {
+		log.Printf("Error writing OpenAPI response: %v", err)
+	}
+}
+
+// parseResultFilter reads the filter query parameters
+func parseResultFilter(query url.Values) (resultFilter, error) {
+	filter := resultFilter{
+		cluster:   query.Get("cluster"),
+		namespace: query.Get("namespace"),
+		service:   query.Get("service"),
+		module:    query.Get("module"),
+		search:    strings.ToLower(strings.TrimSpace(query.Get("q"))),
+	}
+	if name := query.Get("status"); name != "" {
+		status, ok := domain.ParseStatus(name)
+		if !ok {
+			return filter, fmt.Errorf("invalid status %q (expected SYNC, MISMATCH or UNKNOWN)", name)
+		}
+		filter.status = status
+	}
+	return filter, nil
+}
+
+// matches reports whether a result is selected by the filter
+func (f resultFilter) matches(result *domain.ScanResult) bool {
+	return (f.cluster == "" || result.ClusterName == f.cluster) &&
+		(f.namespace == "" || result.PodNamespace == f.namespace) &&
+		(f.service == "" || result.ServiceName == f.service) &&
+		(f.module == "" || result.BSRModule == f.module) &&
+		(f.status == "" || result.Status == f.status) &&
+		(f.search == "" || resultContains(result, f.search))
+}
+
+// resultContains reports whether any of the names, the module, the image or
+// the message of a result contains the lowercase term
+func resultContains(result *domain.ScanResult, term string) bool {
+	fields := []string{
+		result.ClusterName, result.PodNamespace, result.PodName, result.ServiceName,
+		result.KubeService, result.BSRModule, result.Image, result.Message,
+	}
+	if result.Workload != nil {
+		fields = append(fields, result.Workload.Name)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesService reports whether a service summary is selected by the filter
+func (f resultFilter) matchesService(service domain.ServiceSummary) bool {
+	if f.namespace != "" {
+		index := sort.SearchStrings(service.Namespaces, f.namespace)
+		if index == len(service.Namespaces) || service.Namespaces[index] != f.namespace {
+			return false
+		}
+	}
+	if f.module != "" {
+		index := sort.SearchStrings(service.BSRModules, f.module)
+		if index == len(service.BSRModules) || service.BSRModules[index] != f.module {
+			return false
+		}
+	}
+	return (f.cluster == "" || service.ClusterName == f.cluster) &&
+		(f.service == "" || service.ServiceName == f.service) &&
+		(f.status == "" || service.Status == f.status) &&
+		(f.search == "" || strings.Contains(strings.ToLower(service.ServiceName), f.search))
+}
+
+// parsePage reads the limit and offset query parameters
+func parsePage(query url.Values) (int, int, error) {
+	limit, offset := defaultPageLimit, 0
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxPageLimit {
+			return 0, 0, fmt.Errorf("invalid limit %q (expected 1-%d)", value, maxPageLimit)
+		}
+		limit = parsed
+	}
+	if value := query.Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", value)
+		}
+		offset = parsed
+	}
+	return limit, offset, nil
+}
+
+// pageBounds returns the slice bounds of a page within total items
+func pageBounds(total, limit, offset int) (int, int) {
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return offset, end
+}
+
+// sortResults orders results by cluster, namespace, pod name and Kubernetes
+// Service (a pod may have a result per Service), so pages are stable
+func sortResults(results []*domain.ScanResult) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.ClusterName != b.ClusterName {
+			return a.ClusterName < b.ClusterName
+		}
+		if a.PodNamespace != b.PodNamespace {
+			return a.PodNamespace < b.PodNamespace
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.KubeService < b.KubeService
+	})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Error writing API response: %v", err)
+	}
+}
+
+// writeAPIError writes a JSON error response
+func writeAPIError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, apiError{Error: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// testResults are stored in the order they'd come out of a map, so sorting is exercised
+func testResults() []*domain.ScanResult {
+	checked := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return []*domain.ScanResult{
+		{ClusterName: "default", PodNamespace: "staging", PodName: "orders-a", ServiceName: "orders",
+			BSRModule: "buf.build/acme/orders", Status: domain.StatusUnknown, Message: "connection refused", LastChecked: checked},
+		{ClusterName: "default", PodNamespace: "prod", PodName: "users-b", ServiceName: "users",
+			BSRModule: "buf.build/acme/users", Status: domain.StatusMismatch, LastChecked: checked},
+		{ClusterName: "eu/west", PodNamespace: "prod", PodName: "users-c", ServiceName: "users",
+			BSRModule: "buf.build/acme/users", Status: domain.StatusSync, LastChecked: checked},
+		{ClusterName: "default", PodNamespace: "prod", PodName: "gateway-a", ServiceName: "gateway", KubeService: "gateway-grpc",
+			BSRModule: "buf.build/acme/gateway", Status: domain.StatusSync, LastChecked: checked},
+		{ClusterName: "default", PodNamespace: "prod", PodName: "users-a", ServiceName: "users",
+			BSRModule: "buf.build/acme/users", Status: domain.StatusSync, LastChecked: checked},
+		{ClusterName: "default", PodNamespace: "prod", PodName: "gateway-a", ServiceName: "gateway", KubeService: "gateway-admin",
+			BSRModule: "buf.build/acme/gateway", Status: domain.StatusMismatch, LastChecked: checked},
+	}
+}
+
+// newTestServer creates a server over a memory store holding results
+func newTestServer(t *testing.T, results ...*domain.ScanResult) *Server {
+	t.Helper()
+	s := store.NewMemory(store.Options{})
+	for _, result := range results {
+		s.Set(result)
+	}
+	server, err := NewServer(s, "")
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return server
+}
+
+// serveAPI serves a request to the API of a server holding the test results
+func serveAPI(t *testing.T, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	newTestServer(t, testResults()...).registerAPI(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+// resultPage is a page of results as returned by the API
+type resultPage struct {
+	Items  []*domain.ScanResult `json:"items"`
+	Total  int                  `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// getResults lists results and returns the page with the keys of its items
+func getResults(t *testing.T, query string) (resultPage, []string) {
+	t.Helper()
+	w := serveAPI(t, http.MethodGet, APIPrefix+"/results"+query)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET results%s: status %d, want 200: %s", query, w.Code, w.Body)
+	}
+	var page resultPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode page %s: %v", w.Body, err)
+	}
+	keys := []string{}
+	for _, result := range page.Items {
+		keys = append(keys, result.Key().String())
+	}
+	return page, keys
+}
+
+func TestAPIResultsFilters(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{
+			"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc", "default/prod/users-a",
+			"default/prod/users-b", "default/staging/orders-a", "eu/west/prod/users-c",
+		}},
+		{"?cluster=eu/west", []string{"eu/west/prod/users-c"}},
+		{"?namespace=staging", []string{"default/staging/orders-a"}},
+		{"?service=users&cluster=default", []string{"default/prod/users-a", "default/prod/users-b"}},
+		{"?status=mismatch", []string{"default/prod/gateway-a/gateway-admin", "default/prod/users-b"}},
+		{"?module=buf.build/acme/orders", []string{"default/staging/orders-a"}},
+		{"?q=REFUSED", []string{"default/staging/orders-a"}},
+		{"?q=gateway-grpc", []string{"default/prod/gateway-a/gateway-grpc"}},
+		{"?namespace=dev", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			page, keys := getResults(t, tt.query)
+			if strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("results = %v, want %v", keys, tt.want)
+			}
+			if page.Total != len(tt.want) {
+				t.Errorf("total = %d, want %d", page.Total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestAPIResultsPagination(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       []string
+		wantLimit  int
+		wantOffset int
+	}{
+		{"?limit=2", []string{"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc"}, 2, 0},
+		{"?limit=2&offset=1", []string{"default/prod/gateway-a/gateway-grpc", "default/prod/users-a"}, 2, 1},
+		{"?limit=2&offset=5", []string{"eu/west/prod/users-c"}, 2, 5},
+		{"?offset=6", []string{}, defaultPageLimit, 6},
+		{"?offset=100", []string{}, defaultPageLimit, 100},
+		{"?limit=1000", nil, maxPageLimit, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Pages are the same however the store orders results
+			for i := 0; i < 5; i++ {
+				page, keys := getResults(t, tt.query)
+				if tt.want != nil && strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+					t.Fatalf("results = %v, want %v", keys, tt.want)
+				}
+				if page.Total != len(testResults()) || page.Limit != tt.wantLimit || page.Offset != tt.wantOffset {
+					t.Fatalf("total %d, limit %d, offset %d, want %d, %d, %d",
+						page.Total, page.Limit, page.Offset, len(testResults()), tt.wantLimit, tt.wantOffset)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIBadRequests(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		want   int
+	}{
+		{http.MethodGet, APIPrefix + "/results?status=drifted", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/results?limit=0", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/results?limit=1001", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/results?limit=ten", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/results?offset=-1", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/services?status=drifted", http.StatusBadRequest},
+		{http.MethodGet, APIPrefix + "/services?limit=-5", http.StatusBadRequest},
+		{http.MethodPost, APIPrefix + "/results", http.StatusMethodNotAllowed},
+		{http.MethodGet, APIPrefix + "/results/default/prod", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			w := serveAPI(t, tt.method, tt.target)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			var body apiError
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error == "" {
+				t.Errorf("body = %s, want a JSON error", w.Body)
+			}
+		})
+	}
+}
+
+func TestAPIResult(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantKey    string
+	}{
+		{"/default/prod/users-a", http.StatusOK, "default/prod/users-a"},
+		{"/eu/west/prod/users-c", http.StatusOK, "eu/west/prod/users-c"},
+		{"/default/prod/gateway-a?kube_service=gateway-admin", http.StatusOK, "default/prod/gateway-a/gateway-admin"},
+		{"/default/prod/gateway-a?kube_service=gateway-grpc", http.StatusOK, "default/prod/gateway-a/gateway-grpc"},
+		// A pod reached through Services only has results per Service
+		{"/default/prod/gateway-a", http.StatusNotFound, ""},
+		{"/default/prod/users-z", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := serveAPI(t, http.MethodGet, APIPrefix+"/results"+tt.path)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var result domain.ScanResult
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode result %s: %v", w.Body, err)
+			}
+			if key := result.Key().String(); key != tt.wantKey {
+				t.Errorf("result = %s, want %s", key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestAPIServices(t *testing.T) {
+	w := serveAPI(t, http.MethodGet, APIPrefix+"/services?cluster=default&status=MISMATCH")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+	var page struct {
+		Items []domain.ServiceSummary `json:"items"`
+		Total int                     `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode page %s: %v", w.Body, err)
+	}
+	var names []string
+	for _, service := range page.Items {
+		names = append(names, service.ServiceName)
+	}
+	if strings.Join(names, ",") != "gateway,users" || page.Total != 2 {
+		t.Errorf("services = %v (total %d), want gateway and users", names, page.Total)
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"html/template"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Dashboard sort orders, selected with the sort query parameter
+const (
+	// sortByName orders results by service and pod name
+	sortByName = "name"
+	// sortByChecked puts the most recently checked results first
+	sortByChecked = "checked"
+	// sortByStatus puts drifted results first, then unknown, then in sync
+	sortByStatus = "status"
+)
+
+// Dashboard groupings, selected with the group query parameter
+const (
+	groupCluster  = "cluster"
+	groupService  = "service"
+	groupWorkload = "workload"
+	groupNone     = "none"
+)
+
+// statusRank orders statuses for sortByStatus
+var statusRank = map[domain.DiffStatus]int{
+	domain.StatusMismatch: 0,
+	domain.StatusUnknown:  1,
+	domain.StatusSync:     2,
+}
+
+// DashboardView holds the filters, sort order and grouping of the dashboard.
+// It is read from the query parameters, so views can be bookmarked and shared.
+type DashboardView struct {
+	Cluster   string
+	Namespace string
+	Service   string
+	Module    string
+	Status    string
+	// Query is the free-text search term
+	Query string
+	Sort  string
+	Group string
+}
+
+// DashboardOptions lists the values offered by the dashboard's filters
+type DashboardOptions struct {
+	Clusters   []string
+	Namespaces []string
+	Services   []string
+	Modules    []string
+}
+
+// ResultGroup holds the scan results shown under one group heading
+type ResultGroup struct {
+	Name    string
+	Results []*domain.ScanResult
+	// ClusterName and ServiceName identify the service of a service group
+	ClusterName string
+	ServiceName string
+}
+
+// parseDashboardView reads the dashboard query parameters. Unknown sort
+// orders, groupings and statuses fall back to the defaults.
+func parseDashboardView(query url.Values) DashboardView {
+	view := DashboardView{
+		Cluster:   query.Get("cluster"),
+		Namespace: query.Get("namespace"),
+		Service:   query.Get("service"),
+		Module:    query.Get("module"),
+		Query:     strings.TrimSpace(query.Get("q")),
+		Sort:      sortByName,
+		Group:     groupCluster,
+	}
+	if status, ok := domain.ParseStatus(query.Get("status")); ok {
+		view.Status = string(status)
+	}
+	switch sortOrder := query.Get("sort"); sortOrder {
+	case sortByChecked, sortByStatus:
+		view.Sort = sortOrder
+	}
+	switch group := query.Get("group"); group {
+	case groupService, groupWorkload, groupNone:
+		view.Group = group
+	}
+	return view
+}
+
+// Filtered reports whether the view hides any results
+func (v DashboardView) Filtered() bool {
+	return v.Cluster != "" || v.Namespace != "" || v.Service != "" || v.Module != "" || v.Status != "" || v.Query != ""
+}
+
+// With returns the dashboard URL of the view with one parameter replaced, for
+// links that change a single aspect of the view. An empty value removes the
+// parameter.
+func (v DashboardView) With(key, value string) template.URL {
+	query := v.values()
+	if value == "" {
+		query.Del(key)
+	} else {
+		query.Set(key, value)
+	}
+	return dashboardURL(query)
+}
+
+// Unfiltered returns the dashboard URL of the view without its filters,
+// keeping the sort order and grouping
+func (v DashboardView) Unfiltered() template.URL {
+	return dashboardURL(DashboardView{Sort: v.Sort, Group: v.Group}.values())
+}
+
+// dashboardURL builds a dashboard link from encoded query parameters
+func dashboardURL(query url.Values) template.URL {
+	if len(query) == 0 {
+		return "/"
+	}
+	return template.URL("/?" + query.Encode())
+}
+
+// values encodes the view as query parameters, leaving out defaults
+func (v DashboardView) values() url.Values {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"cluster":   v.Cluster,
+		"namespace": v.Namespace,
+		"service":   v.Service,
+		"module":    v.Module,
+		"status":    v.Status,
+		"q":         v.Query,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if v.Sort != sortByName {
+		query.Set("sort", v.Sort)
+	}
+	if v.Group != groupCluster {
+		query.Set("group", v.Group)
+	}
+	return query
+}
+
+// filter returns the result filter of the view
+func (v DashboardView) filter() resultFilter {
+	return resultFilter{
+		cluster:   v.Cluster,
+		namespace: v.Namespace,
+		service:   v.Service,
+		module:    v.Module,
+		status:    domain.DiffStatus(v.Status),
+		search:    strings.ToLower(v.Query),
+	}
+}
+
+// apply filters and sorts results for the view
+func (v DashboardView) apply(results []*domain.ScanResult) []*domain.ScanResult {
+	filter := v.filter()
+	selected := make([]*domain.ScanResult, 0, len(results))
+	for _, result := range results {
+		if filter.matches(result) {
+			selected = append(selected, result)
+		}
+	}
+	sortDashboard(selected, v.Sort)
+	return selected
+}
+
+// dashboardOptions collects the distinct clusters, namespaces, services and modules of the results
+func dashboardOptions(results []*domain.ScanResult) DashboardOptions {
+	clusters := make(map[string]bool)
+	namespaces := make(map[string]bool)
+	services := make(map[string]bool)
+	modules := make(map[string]bool)
+	for _, result := range results {
+		clusters[result.ClusterName] = true
+		namespaces[result.PodNamespace] = true
+		services[result.ServiceName] = true
+		if result.BSRModule != "" {
+			modules[result.BSRModule] = true
+		}
+	}
+	return DashboardOptions{
+		Clusters:   sortedKeys(clusters),
+		Namespaces: sortedKeys(namespaces),
+		Services:   sortedKeys(services),
+		Modules:    sortedKeys(modules),
+	}
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortDashboard orders results by the given sort order, breaking ties by
+// service, pod and Kubernetes Service name
+func sortDashboard(results []*domain.ScanResult, order string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch order {
+		case sortByChecked:
+			if !a.LastChecked.Equal(b.LastChecked) {
+				return a.LastChecked.After(b.LastChecked)
+			}
+		case sortByStatus:
+			if statusRank[a.Status] != statusRank[b.Status] {
+				return statusRank[a.Status] < statusRank[b.Status]
+			}
+		}
+		if a.ServiceName != b.ServiceName {
+			return a.ServiceName < b.ServiceName
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.KubeService < b.KubeService
+	})
+}
+
+// groupResults groups sorted results by cluster, service or workload, keeping
+// their order within each group. Groups are sorted by name; with groupNone all
+// results form a single group.
+func groupResults(results []*domain.ScanResult, by string, multiCluster bool) []ResultGroup {
+	if by == groupNone {
+		if len(results) == 0 {
+			return nil
+		}
+		return []ResultGroup{{Results: results}}
+	}
+
+	byName := make(map[string]*ResultGroup)
+	var groups []*ResultGroup
+	for _, result := range results {
+		name := groupName(result, by, multiCluster)
+		group, ok := byName[name]
+		if !ok {
+			group = &ResultGroup{Name: name}
+			if by == groupService {
+				group.ClusterName = result.ClusterName
+				group.ServiceName = result.ServiceName
+			}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.Results = append(group.Results, result)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	sorted := make([]ResultGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, *group)
+	}
+	return sorted
+}
+
+// groupName returns the heading of the group a result belongs to
+func groupName(result *domain.ScanResult, by string, multiCluster bool) string {
+	var name string
+	switch by {
+	case groupService:
+		name = result.ServiceName
+	case groupWorkload:
+		if result.Workload == nil {
+			name = result.PodNamespace + "/(no workload)"
+		} else {
+			name = result.PodNamespace + "/" + result.Workload.Kind + "/" + result.Workload.Name
+		}
+	default:
+		return result.ClusterName
+	}
+	if multiCluster {
+		name += " @ " + result.ClusterName
+	}
+	return name
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// resultKeys returns the keys of results in order
+func resultKeys(results []*domain.ScanResult) []string {
+	keys := []string{}
+	for _, result := range results {
+		keys = append(keys, result.Key().String())
+	}
+	return keys
+}
+
+func TestParseDashboardView(t *testing.T) {
+	tests := []struct {
+		query string
+		want  DashboardView
+	}{
+		{"", DashboardView{Sort: sortByName, Group: groupCluster}},
+		{"cluster=eu%2Fwest&namespace=prod&service=users&module=buf.build%2Facme%2Fusers&q=+Users+",
+			DashboardView{Cluster: "eu/west", Namespace: "prod", Service: "users", Module: "buf.build/acme/users",
+				Query: "Users", Sort: sortByName, Group: groupCluster}},
+		{"status=mismatch&sort=status&group=workload",
+			DashboardView{Status: "MISMATCH", Sort: sortByStatus, Group: groupWorkload}},
+		{"sort=checked&group=none", DashboardView{Sort: sortByChecked, Group: groupNone}},
+		{"status=drifted&sort=random&group=pod", DashboardView{Sort: sortByName, Group: groupCluster}},
+	}
+	for _, tt := range tests {
+		query, err := url.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("invalid query %q: %v", tt.query, err)
+		}
+		if got := parseDashboardView(query); got != tt.want {
+			t.Errorf("parseDashboardView(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDashboardViewLinks(t *testing.T) {
+	view := DashboardView{Service: "users", Status: "MISMATCH", Sort: sortByStatus, Group: groupNone}
+	tests := []struct {
+		name string
+		got  template.URL
+		want template.URL
+	}{
+		{"With sets", view.With("cluster", "eu/west"), "/?cluster=eu%2Fwest&group=none&service=users&sort=status&status=MISMATCH"},
+		{"With replaces", view.With("status", "SYNC"), "/?group=none&service=users&sort=status&status=SYNC"},
+		{"With removes", view.With("service", ""), "/?group=none&sort=status&status=MISMATCH"},
+		{"Unfiltered", view.Unfiltered(), "/?group=none&sort=status"},
+		{"defaults", DashboardView{Sort: sortByName, Group: groupCluster}.Unfiltered(), "/"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDashboardViewApply(t *testing.T) {
+	tests := []struct {
+		name string
+		view DashboardView
+		want []string
+	}{
+		{"by name", DashboardView{Sort: sortByName}, []string{
+			"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc", "default/staging/orders-a",
+			"default/prod/users-a", "default/prod/users-b", "eu/west/prod/users-c",
+		}},
+		{"by status", DashboardView{Sort: sortByStatus}, []string{
+			"default/prod/gateway-a/gateway-admin", "default/prod/users-b", "default/staging/orders-a",
+			"default/prod/gateway-a/gateway-grpc", "default/prod/users-a", "eu/west/prod/users-c",
+		}},
+		{"by checked", DashboardView{Sort: sortByChecked}, []string{
+			"eu/west/prod/users-c", "default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc",
+			"default/staging/orders-a", "default/prod/users-a", "default/prod/users-b",
+		}},
+		{"status", DashboardView{Status: "MISMATCH", Sort: sortByName}, []string{
+			"default/prod/gateway-a/gateway-admin", "default/prod/users-b",
+		}},
+		{"cluster and service", DashboardView{Cluster: "default", Service: "users", Sort: sortByName}, []string{
+			"default/prod/users-a", "default/prod/users-b",
+		}},
+		{"namespace", DashboardView{Namespace: "staging", Sort: sortByName}, []string{"default/staging/orders-a"}},
+		{"module", DashboardView{Module: "buf.build/acme/gateway", Sort: sortByName}, []string{
+			"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc",
+		}},
+		{"search message", DashboardView{Query: "Refused", Sort: sortByName}, []string{"default/staging/orders-a"}},
+		{"search Kubernetes Service", DashboardView{Query: "admin", Sort: sortByName}, []string{"default/prod/gateway-a/gateway-admin"}},
+		{"no match", DashboardView{Cluster: "eu/west", Status: "MISMATCH", Sort: sortByName}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := testResults()
+			// users-c was checked last
+			results[2].LastChecked = results[2].LastChecked.Add(time.Minute)
+			if got := resultKeys(tt.view.apply(results)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupResults(t *testing.T) {
+	results := DashboardView{Sort: sortByName}.apply(testResults())
+	results[3].Workload = &domain.WorkloadRef{Kind: "Deployment", Name: "users"}
+	results[4].Workload = &domain.WorkloadRef{Kind: "Deployment", Name: "users"}
+
+	type group struct {
+		Name    string
+		Service string
+		Results []string
+	}
+	tests := []struct {
+		by           string
+		multiCluster bool
+		want         []group
+	}{
+		{groupCluster, true, []group{
+			{"default", "", []string{
+				"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc", "default/staging/orders-a",
+				"default/prod/users-a", "default/prod/users-b",
+			}},
+			{"eu/west", "", []string{"eu/west/prod/users-c"}},
+		}},
+		{groupService, false, []group{
+			{"gateway", "default/gateway", []string{"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc"}},
+			{"orders", "default/orders", []string{"default/staging/orders-a"}},
+			// Without the cluster in the name, the services of both clusters share a group
+			{"users", "default/users", []string{"default/prod/users-a", "default/prod/users-b", "eu/west/prod/users-c"}},
+		}},
+		{groupService, true, []group{
+			{"gateway @ default", "default/gateway", []string{"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc"}},
+			{"orders @ default", "default/orders", []string{"default/staging/orders-a"}},
+			{"users @ default", "default/users", []string{"default/prod/users-a", "default/prod/users-b"}},
+			{"users @ eu/west", "eu/west/users", []string{"eu/west/prod/users-c"}},
+		}},
+		{groupWorkload, true, []group{
+			{"prod/(no workload) @ default", "", []string{"default/prod/gateway-a/gateway-admin", "default/prod/gateway-a/gateway-grpc"}},
+			{"prod/(no workload) @ eu/west", "", []string{"eu/west/prod/users-c"}},
+			{"prod/Deployment/users @ default", "", []string{"default/prod/users-a", "default/prod/users-b"}},
+			{"staging/(no workload) @ default", "", []string{"default/staging/orders-a"}},
+		}},
+		{groupNone, true, []group{
+			{"", "", resultKeys(results)},
+		}},
+	}
+	for _, tt := range tests {
+		var got []group
+		for _, g := range groupResults(results, tt.by, tt.multiCluster) {
+			service := ""
+			if g.ServiceName != "" {
+				service = g.ClusterName + "/" + g.ServiceName
+			}
+			got = append(got, group{Name: g.Name, Service: service, Results: resultKeys(g.Results)})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("groupResults(%s, %t) = %+v, want %+v", tt.by, tt.multiCluster, got, tt.want)
+		}
+	}
+
+	if groups := groupResults(nil, groupNone, false); groups != nil {
+		t.Errorf("groupResults(nil, none) = %+v, want nil", groups)
+	}
+}
+
+func TestDashboardOptions(t *testing.T) {
+	results := append(testResults(), &domain.ScanResult{
+		ClusterName: "default", PodNamespace: "tools", PodName: "debug", ServiceName: "debug", Status: domain.StatusUnknown,
+	})
+	want := DashboardOptions{
+		Clusters:   []string{"default", "eu/west"},
+		Namespaces: []string{"prod", "staging", "tools"},
+		Services:   []string{"debug", "gateway", "orders", "users"},
+		// Results without a module don't add an empty option
+		Modules: []string{"buf.build/acme/gateway", "buf.build/acme/orders", "buf.build/acme/users"},
+	}
+	if got := dashboardOptions(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("dashboardOptions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleDashboard(t *testing.T) {
+	server := newTestServer(t, testResults()...)
+	w := httptest.NewRecorder()
+	server.handleDashboard(w, httptest.NewRequest(http.MethodGet, "/?status=mismatch&sort=status&group=none", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /: status %d, want 200: %s", w.Code, w.Body)
+	}
+
+	var keys []string
+	for _, match := range regexp.MustCompile(`<tr[^>]*data-key="([^"]*)"`).FindAllStringSubmatch(w.Body.String(), -1) {
+		keys = append(keys, match[1])
+	}
+	want := []string{"default/prod/gateway-a/gateway-admin", "default/prod/users-b"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("dashboard rows = %v, want %v", keys, want)
+	}
+}
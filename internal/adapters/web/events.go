@@ -0,0 +1,163 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// eventsKeepAlive is how often an idle event stream sends a comment, so
+// proxies don't close it
+const eventsKeepAlive = 30 * time.Second
+
+// RemovedResult identifies a pod whose result was removed
+type RemovedResult struct {
+	ClusterName  string `json:"cluster_name"`
+	PodNamespace string `json:"pod_namespace"`
+	PodName      string `json:"pod_name"`
+	KubeService  string `json:"kube_service,omitempty"`
+}
+
+// progressHub keeps the latest scan progress and fans it out to event streams
+type progressHub struct {
+	mu     sync.Mutex
+	latest *domain.ScanProgress
+	subs   map[chan domain.ScanProgress]struct{}
+}
+
+// ReportProgress publishes the progress of a scan cycle to the dashboard
+func (s *Server) ReportProgress(progress domain.ScanProgress) {
+	s.progress.publish(progress)
+}
+
+// publish stores the progress and hands it to every subscriber. Only the
+// latest progress matters, so a subscriber that hasn't read the previous one
+// gets it replaced.
+func (h *progressHub) publish(progress domain.ScanProgress) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latest = &progress
+	for ch := range h.subs {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- progress
+	}
+}
+
+// subscribe returns a channel receiving progress updates, primed with the
+// latest one, and a function ending the subscription
+func (h *progressHub) subscribe() (<-chan domain.ScanProgress, func()) {
+	ch := make(chan domain.ScanProgress, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[chan domain.ScanProgress]struct{})
+	}
+	h.subs[ch] = struct{}{}
+	if h.latest != nil {
+		ch <- *h.latest
+	}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, ch)
+	}
+}
+
+// handleEvents streams result changes and scan progress as Server-Sent Events:
+//
+//	event: result    a stored or updated ScanResult
+//	event: removed   a RemovedResult
+//	event: progress  a ScanProgress
+//	event: resync    changes were missed, the client should reload
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	changes, unsubscribe := s.store.Subscribe()
+	defer unsubscribe()
+	progress, unsubscribeProgress := s.progress.subscribe()
+	defer unsubscribeProgress()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				writeEvent(w, "resync", struct{}{})
+				flusher.Flush()
+				return
+			}
+			err = writeChange(w, change)
+		case p := <-progress:
+			err = writeEvent(w, "progress", p)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeChange writes a store change as a result or removed event
+func writeChange(w http.ResponseWriter, change store.Change) error {
+	if change.Type == store.ChangeDelete {
+		return writeEvent(w, "removed", RemovedResult{
+			ClusterName:  change.Result.ClusterName,
+			PodNamespace: change.Result.PodNamespace,
+			PodName:      change.Result.PodName,
+			KubeService:  change.Result.KubeService,
+		})
+	}
+	return writeEvent(w, "result", change.Result)
+}
+
+// writeEvent writes a named Server-Sent Event with a JSON payload
+func writeEvent(w http.ResponseWriter, event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// sseEvent is a Server-Sent Event read from a stream
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event from a stream, skipping comments
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event.name != "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestEventsStreamsResultsAndRemovals(t *testing.T) {
+	server := newTestServer(t)
+	ts := httptest.NewServer(http.HandlerFunc(server.handleEvents))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("got %s %q, want 200 text/event-stream", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// The stream subscribes before sending its headers, so nothing is missed
+	result := &domain.ScanResult{
+		ClusterName:  "default",
+		PodNamespace: "prod",
+		PodName:      "gateway-a",
+		ServiceName:  "gateway",
+		KubeService:  "gateway-grpc",
+		Status:       domain.StatusMismatch,
+		LastChecked:  time.Now(),
+	}
+	server.store.Set(result)
+	event := readEvent(t, reader)
+	var got domain.ScanResult
+	if event.name != "result" || json.Unmarshal([]byte(event.data), &got) != nil {
+		t.Fatalf("got event %q %s, want a result", event.name, event.data)
+	}
+	if got.Key() != result.Key() || got.Status != domain.StatusMismatch {
+		t.Errorf("result event for %s %s, want %s MISMATCH", got.Key(), got.Status, result.Key())
+	}
+
+	server.ReportProgress(domain.ScanProgress{Running: true, PodsScanned: 1, PodCount: 3})
+	event = readEvent(t, reader)
+	var progress domain.ScanProgress
+	if event.name != "progress" || json.Unmarshal([]byte(event.data), &progress) != nil {
+		t.Fatalf("got event %q %s, want progress", event.name, event.data)
+	}
+	if !progress.Running || progress.PodsScanned != 1 || progress.PodCount != 3 {
+		t.Errorf("progress = %+v, want 1 of 3 pods scanned", progress)
+	}
+
+	server.store.Delete(result.Key())
+	event = readEvent(t, reader)
+	var removed RemovedResult
+	if event.name != "removed" || json.Unmarshal([]byte(event.data), &removed) != nil {
+		t.Fatalf("got event %q %s, want a removal", event.name, event.data)
+	}
+	want := RemovedResult{ClusterName: "default", PodNamespace: "prod", PodName: "gateway-a", KubeService: "gateway-grpc"}
+	if removed != want {
+		t.Errorf("removed = %+v, want %+v", removed, want)
+	}
+}
+
+// overflowedStore drops its subscribers right away, as it does those falling too far behind
+type overflowedStore struct {
+	*store.Memory
+}
+
+func (s overflowedStore) Subscribe() (<-chan store.Change, func()) {
+	changes := make(chan store.Change)
+	close(changes)
+	return changes, func() {}
+}
+
+func TestEventsAsksForResyncWhenChangesWereMissed(t *testing.T) {
+	server, err := NewServer(overflowedStore{store.NewMemory(store.Options{})}, "")
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	w := httptest.NewRecorder()
+	// The handler returns once it told the client to resync
+	server.handleEvents(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	event := readEvent(t, bufio.NewReader(w.Body))
+	if event.name != "resync" {
+		t.Errorf("got event %q, want resync", event.name)
+	}
+}
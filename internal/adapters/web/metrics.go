@@ -0,0 +1,194 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// metricsNamespace prefixes every exported metric
+const metricsNamespace = "protodiff"
+
+// metrics holds the Prometheus metrics served at /metrics
+type metrics struct {
+	registry      *prometheus.Registry
+	scanDuration  prometheus.Histogram
+	lastSuccess   prometheus.Gauge
+	probeDuration prometheus.Histogram
+	probeErrors   *prometheus.CounterVec
+	bsrDuration   prometheus.Histogram
+	bsrErrors     *prometheus.CounterVec
+}
+
+// newMetrics creates the metrics and registers them with a fresh registry,
+// together with gauges computed from the stored results on every scrape
+func newMetrics(store store.Store) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		scanDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "scan_duration_seconds",
+			Help:      "Duration of scan cycles across all clusters.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 13),
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_successful_scan_timestamp_seconds",
+			Help:      "Unix time of the last scan cycle that scanned every cluster.",
+		}),
+		probeDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "probe_duration_seconds",
+			Help:      "Duration of live schema fetches through gRPC reflection, including port-forwarding.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}),
+		probeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "probe_errors_total",
+			Help:      "Failed live schema fetches by reason.",
+		}, []string{"reason"}),
+		bsrDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "bsr_fetch_duration_seconds",
+			Help:      "Duration of BSR schema fetches.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		}),
+		bsrErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bsr_fetch_errors_total",
+			Help:      "Failed BSR schema fetches by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		m.scanDuration,
+		m.lastSuccess,
+		m.probeDuration,
+		m.probeErrors,
+		m.bsrDuration,
+		m.bsrErrors,
+		resultsCollector{store: store},
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// handler serves the metrics in the Prometheus exposition format
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveScan records the duration of a scan cycle, and its time if every
+// cluster was scanned
+func (s *Server) ObserveScan(duration time.Duration, err error) {
+	s.metrics.scanDuration.Observe(duration.Seconds())
+	if err == nil {
+		s.metrics.lastSuccess.SetToCurrentTime()
+	}
+}
+
+// ObserveProbe records a live schema fetch; reason is "" on success
+func (s *Server) ObserveProbe(duration time.Duration, reason string) {
+	s.metrics.probeDuration.Observe(duration.Seconds())
+	if reason != "" {
+		s.metrics.probeErrors.WithLabelValues(reason).Inc()
+	}
+}
+
+// ObserveBSRFetch records a BSR schema fetch; reason is "" on success
+func (s *Server) ObserveBSRFetch(duration time.Duration, reason string) {
+	s.metrics.bsrDuration.Observe(duration.Seconds())
+	if reason != "" {
+		s.metrics.bsrErrors.WithLabelValues(reason).Inc()
+	}
+}
+
+var (
+	resultsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "results"),
+		"Current scan results by cluster, namespace, service and status.",
+		[]string{"cluster", "namespace", "service", "status"}, nil,
+	)
+	driftedMethodsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "service_drifted_methods"),
+		"Distinct methods missing from or extra in the live schema across the pods of a service.",
+		[]string{"cluster", "service"}, nil,
+	)
+)
+
+// resultsCollector computes gauges from the stored results on every scrape,
+// so they never disagree with the dashboard
+type resultsCollector struct {
+	store store.Store
+}
+
+// resultsKey identifies a series of the results gauge
+type resultsKey struct {
+	cluster, namespace, service string
+	status                      domain.DiffStatus
+}
+
+// serviceKey identifies a service across its namespaces
+type serviceKey struct {
+	cluster, service string
+}
+
+// Describe implements prometheus.Collector
+func (c resultsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resultsDesc
+	ch <- driftedMethodsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c resultsCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := make(map[resultsKey]int)
+	drifted := make(map[serviceKey]map[string]bool)
+	for _, result := range c.store.GetAll() {
+		counts[resultsKey{result.ClusterName, result.PodNamespace, result.ServiceName, result.Status}]++
+
+		key := serviceKey{result.ClusterName, result.ServiceName}
+		if drifted[key] == nil {
+			drifted[key] = make(map[string]bool)
+		}
+		if result.Status != domain.StatusMismatch || result.SchemaDiff == nil {
+			continue
+		}
+		for _, mismatch := range result.SchemaDiff.MethodMismatches {
+			for _, method := range mismatch.MissingMethods {
+				drifted[key][mismatch.ServiceName+"/"+method] = true
+			}
+			for _, method := range mismatch.ExtraMethods {
+				drifted[key][mismatch.ServiceName+"/"+method] = true
+			}
+		}
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(resultsDesc, prometheus.GaugeValue, float64(count),
+			key.cluster, key.namespace, key.service, string(key.status))
+	}
+	for key, methods := range drifted {
+		ch <- prometheus.MustNewConstMetric(driftedMethodsDesc, prometheus.GaugeValue, float64(len(methods)),
+			key.cluster, key.service)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/protoset"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// PodPageData represents the data passed to the pod template
+type PodPageData struct {
+	Result *domain.ScanResult
+	// Tree is the side-by-side live vs BSR schema, nil if it couldn't be built
+	Tree []domain.SchemaTreeNode
+	// TreeError explains why the tree is missing
+	TreeError string
+	// LiveOnly, BSROnly and Changed count the differences in the tree
+	LiveOnly int
+	BSROnly  int
+	Changed  int
+	// ShowAll includes declarations without differences
+	ShowAll bool
+	// BSRURL links to the BSR module at the compared commit
+	BSRURL     string
+	LastUpdate string
+	Rescan     bool
+}
+
+// resultKeyFromQuery reads the result key from the cluster, namespace, pod and
+// kube_service query parameters
+func resultKeyFromQuery(query url.Values) domain.ResultKey {
+	return domain.ResultKey{
+		ClusterName: query.Get("cluster"),
+		Namespace:   query.Get("namespace"),
+		PodName:     query.Get("pod"),
+		KubeService: query.Get("kube_service"),
+	}
+}
+
+// handlePod renders the side-by-side live vs BSR schema of a pod:
+//
+//	GET /pod?cluster=&namespace=&pod=&kube_service=&all=1
+func (s *Server) handlePod(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result, ok := s.store.Get(resultKeyFromQuery(query))
+	if !ok {
+		http.Error(w, "pod not found", http.StatusNotFound)
+		return
+	}
+
+	data := PodPageData{
+		Result:     result,
+		ShowAll:    query.Get("all") != "",
+		BSRURL:     domain.BSRModuleURL(result.BSRModule, result.BSRCommit),
+		LastUpdate: time.Now().Format("2006-01-02 15:04:05"),
+		Rescan:     s.rescanner != nil,
+	}
+	tree, err := s.schemaTree(result)
+	if err != nil {
+		data.TreeError = err.Error()
+	} else {
+		data.Tree = tree
+		counts := domain.CountSchemaChanges(tree)
+		data.LiveOnly = counts[domain.SchemaLiveOnly]
+		data.BSROnly = counts[domain.SchemaBSROnly]
+		data.Changed = counts[domain.SchemaChanged]
+		// Without differences there is nothing to hide
+		if data.LiveOnly+data.BSROnly+data.Changed == 0 {
+			data.ShowAll = true
+		}
+	}
+
+	s.render(w, "pod", data)
+}
+
+// schemaTree compares the stored live and BSR schemas of a result. Only the
+// services that were compared are included, so services ignored through pod
+// annotations don't show up as differences.
+func (s *Server) schemaTree(result *domain.ScanResult) ([]domain.SchemaTreeNode, error) {
+	var liveServices, bsrServices []string
+	if result.SchemaDiff != nil {
+		liveServices = append([]string{}, result.SchemaDiff.LiveServices...)
+		bsrServices = append([]string{}, result.SchemaDiff.BSRServices...)
+	}
+
+	live, err := s.outlineSnapshot(result.LiveSnapshot, schemaSourceLive, liveServices)
+	if err != nil {
+		return nil, err
+	}
+	bsr, err := s.outlineSnapshot(result.BSRSnapshot, schemaSourceBSR, bsrServices)
+	if err != nil {
+		return nil, err
+	}
+	return domain.CompareSchemaDecls(live, bsr), nil
+}
+
+// outlineSnapshot outlines the services of a stored schema snapshot
+func (s *Server) outlineSnapshot(id, source string, services []string) ([]domain.SchemaDecl, error) {
+	if id == "" {
+		return nil, fmt.Errorf("no %s schema was recorded for this pod", source)
+	}
+	data, ok := s.store.GetSnapshot(id)
+	if !ok {
+		return nil, fmt.Errorf("%s schema snapshot %s is no longer available", source, id)
+	}
+	decls, err := protoset.Outline(data, services)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", source, err)
+	}
+	return decls, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlePod(t *testing.T) {
+	results := testResults()
+	results[3].Message = "gRPC port in sync"
+	results[5].Message = "admin port drifted"
+	server := newTestServer(t, results...)
+
+	tests := []struct {
+		target   string
+		wantCode int
+		want     string
+	}{
+		{"/pod?cluster=default&namespace=prod&pod=gateway-a&kube_service=gateway-grpc", http.StatusOK, "gRPC port in sync"},
+		{"/pod?cluster=default&namespace=prod&pod=gateway-a&kube_service=gateway-admin", http.StatusOK, "admin port drifted"},
+		{"/pod?cluster=eu%2Fwest&namespace=prod&pod=users-c", http.StatusOK, "<code>users-c</code>"},
+		// A pod behind several Services is only found with its Service
+		{"/pod?cluster=default&namespace=prod&pod=gateway-a", http.StatusNotFound, ""},
+		{"/pod?cluster=eu%2Fwest&namespace=prod&pod=users-a", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		server.handlePod(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if w.Code != tt.wantCode {
+			t.Errorf("GET %s: status %d, want %d", tt.target, w.Code, tt.wantCode)
+			continue
+		}
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("GET %s: page doesn't show %q", tt.target, tt.want)
+		}
+	}
+}
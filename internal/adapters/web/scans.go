@@ -0,0 +1,117 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// maxScanRequestBody bounds the body of a scan request
+const maxScanRequestBody = 4096
+
+// Rescanner accepts on-demand scans and reports their progress
+type Rescanner interface {
+	// RequestScan queues a scan of the pods selected by the request
+	RequestScan(req domain.ScanRequest) (domain.ScanJob, error)
+	// ScanJob returns an on-demand scan by ID
+	ScanJob(id string) (domain.ScanJob, bool)
+	// ScanJobs returns the recent on-demand scans, newest first
+	ScanJobs() []domain.ScanJob
+}
+
+// SetRescanner enables the on-demand scan endpoints and dashboard buttons.
+// It must be called before Start.
+func (s *Server) SetRescanner(rescanner Rescanner) {
+	s.rescanner = rescanner
+}
+
+// handleAPIScans queues an on-demand scan or lists recent scans:
+//
+//	POST /api/v1/scans {"cluster": "", "namespace": "", "service": "", "pod": ""}
+//	GET  /api/v1/scans
+//
+// The request fields may also be given as query parameters.
+func (s *Server) handleAPIScans(w http.ResponseWriter, r *http.Request) {
+	if s.rescanner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "on-demand scans are not available")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		writeJSON(w, http.StatusOK, s.rescanner.ScanJobs())
+	case http.MethodPost:
+		req, err := parseScanRequest(r)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		job, err := s.rescanner.RequestScan(req)
+		if err != nil {
+			writeAPIError(w, http.StatusServiceUnavailable, "%v", err)
+			return
+		}
+		w.Header().Set("Location", APIPrefix+"/scans/"+job.ID)
+		writeJSON(w, http.StatusAccepted, job)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		writeAPIError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+// handleAPIScan reports the progress of an on-demand scan:
+//
+//	GET /api/v1/scans/{id}
+func (s *Server) handleAPIScan(w http.ResponseWriter, r *http.Request) {
+	if s.rescanner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, "on-demand scans are not available")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, APIPrefix+"/scans/")
+	job, ok := s.rescanner.ScanJob(id)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "no scan with ID %q", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// parseScanRequest reads a scan request from the JSON body, falling back to
+// query parameters when the body is empty
+func parseScanRequest(r *http.Request) (domain.ScanRequest, error) {
+	var req domain.ScanRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, maxScanRequestBody)).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		query := r.URL.Query()
+		req = domain.ScanRequest{
+			ClusterName: query.Get("cluster"),
+			Namespace:   query.Get("namespace"),
+			ServiceName: query.Get("service"),
+			PodName:     query.Get("pod"),
+		}
+	case err != nil:
+		return req, fmt.Errorf("invalid scan request: %w", err)
+	}
+	return req, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/uzdada/protodiff/internal/adapters/protoset"
+)
+
+// InternalSnapshotsPath serves raw schema snapshots to other replicas
+const InternalSnapshotsPath = "/internal/snapshots"
+
+// Schema sources that can be downloaded for a pod
+const (
+	schemaSourceLive = "live"
+	schemaSourceBSR  = "bsr"
+)
+
+// handleSchema serves the live or BSR schema compared for a pod as a binary
+// FileDescriptorSet, JSON or .proto source:
+//
+//	GET /schema?cluster=&namespace=&pod=&kube_service=&source=live|bsr&format=binpb|json|proto
+func (s *Server) handleSchema(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result, ok := s.store.Get(resultKeyFromQuery(query))
+	if !ok {
+		http.Error(w, "pod not found", http.StatusNotFound)
+		return
+	}
+
+	source := query.Get("source")
+	if source == "" {
+		source = schemaSourceLive
+	}
+	var id string
+	switch source {
+	case schemaSourceLive:
+		id = result.LiveSnapshot
+	case schemaSourceBSR:
+		id = result.BSRSnapshot
+	default:
+		http.Error(w, fmt.Sprintf("unknown source %q (expected %s or %s)", source, schemaSourceLive, schemaSourceBSR), http.StatusBadRequest)
+		return
+	}
+	if id == "" {
+		http.Error(w, fmt.Sprintf("no %s schema recorded for this pod", source), http.StatusNotFound)
+		return
+	}
+	data, ok := s.store.GetSnapshot(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("%s schema snapshot %s is no longer available", source, id), http.StatusNotFound)
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = protoset.FormatBinary
+	}
+	body, contentType, err := protoset.Render(data, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%s.%s", result.PodName, source, format)))
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Error writing schema response: %v", err)
+	}
+}
+
+// handleInternalSnapshot returns a raw schema snapshot by ID
+func (s *Server) handleInternalSnapshot(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.store.GetSnapshot(r.URL.Query().Get("id"))
+	if !ok {
+		http.Error(w, "snapshot not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Error writing snapshot response: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// testSnapshot serializes a FileDescriptorSet declaring one message
+func testSnapshot(t *testing.T, message string) []byte {
+	t.Helper()
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:        proto.String("acme/users.proto"),
+			Package:     proto.String("acme"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String(message)}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal snapshot: %v", err)
+	}
+	return data
+}
+
+func TestHandleSchema(t *testing.T) {
+	live, bsr := testSnapshot(t, "LiveUser"), testSnapshot(t, "BSRUser")
+	result := &domain.ScanResult{
+		ClusterName: "default", PodNamespace: "prod", PodName: "users-a", ServiceName: "users",
+		KubeService: "users-grpc", Status: domain.StatusMismatch, LiveSnapshot: "live-1", BSRSnapshot: "bsr-1",
+	}
+	// users-b's BSR snapshot was pruned and its live schema never fetched
+	pruned := &domain.ScanResult{
+		ClusterName: "default", PodNamespace: "prod", PodName: "users-b", ServiceName: "users",
+		Status: domain.StatusUnknown, BSRSnapshot: "bsr-gone",
+	}
+	server := newTestServer(t, result, pruned)
+	server.store.PutSnapshot("live-1", live)
+	server.store.PutSnapshot("bsr-1", bsr)
+
+	const podA = "/schema?cluster=default&namespace=prod&pod=users-a&kube_service=users-grpc"
+	const podB = "/schema?cluster=default&namespace=prod&pod=users-b"
+	tests := []struct {
+		target          string
+		wantStatus      int
+		wantContentType string
+		wantFile        string
+		// wantBody is contained in the body
+		wantBody string
+	}{
+		{podA, http.StatusOK, "application/octet-stream", "users-a-live.binpb", string(live)},
+		{podA + "&source=bsr&format=binpb", http.StatusOK, "application/octet-stream", "users-a-bsr.binpb", string(bsr)},
+		{podA + "&source=live&format=json", http.StatusOK, "application/json", "users-a-live.json", `"LiveUser"`},
+		{podA + "&source=bsr&format=proto", http.StatusOK, "text/plain; charset=utf-8", "users-a-bsr.proto", "message BSRUser"},
+		{podA + "&source=peer", http.StatusBadRequest, "", "", "unknown source"},
+		{podA + "&format=yaml", http.StatusBadRequest, "", "", "unsupported format"},
+		{"/schema?cluster=default&namespace=prod&pod=users-a", http.StatusNotFound, "", "", "pod not found"},
+		{podB + "&source=live", http.StatusNotFound, "", "", "no live schema recorded"},
+		{podB + "&source=bsr", http.StatusNotFound, "", "", "no longer available"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.handleSchema(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body %q does not contain %q", w.Body, tt.wantBody)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="`+tt.wantFile+`"` {
+				t.Errorf("Content-Disposition = %q, want the file %s", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestHandleInternalSnapshot(t *testing.T) {
+	server := newTestServer(t)
+	live := testSnapshot(t, "LiveUser")
+	server.store.PutSnapshot("live-1", live)
+
+	w := httptest.NewRecorder()
+	server.handleInternalSnapshot(w, httptest.NewRequest(http.MethodGet, InternalSnapshotsPath+"?id=live-1", nil))
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), live) {
+		t.Errorf("got %d with %d bytes, want 200 with the raw snapshot", w.Code, w.Body.Len())
+	}
+
+	w = httptest.NewRecorder()
+	server.handleInternalSnapshot(w, httptest.NewRequest(http.MethodGet, InternalSnapshotsPath+"?id=live-2", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status for a missing snapshot = %d, want 404", w.Code)
+	}
+}
@@ -19,11 +19,31 @@
 // template and provides both a main dashboard and a health check endpoint.
 //
 // Endpoints:
-//   - GET /: Main dashboard showing all scan results with statistics
+//   - GET /: Main dashboard showing the scan results grouped by cluster, with
+//     statistics, per-workload status, image history and cross-cluster schema skew.
+//     Query parameters filter (cluster, namespace, service, module, status, q),
+//     sort (sort=name|checked|status) and group (group=cluster|service|workload|none)
+//     the results, so views can be bookmarked
+//   - GET /service?cluster=&name=: Per-service page with the status timeline,
+//     time spent out of sync and the recorded transitions with diff snapshots
+//   - GET /pod?cluster=&namespace=&pod=: Per-pod page with the live and BSR
+//     schemas side by side, highlighting added, removed and changed declarations
+//   - GET /schema?cluster=&namespace=&pod=&source=live|bsr&format=binpb|json|proto:
+//     Download the live or BSR schema compared for a pod
 //   - GET /health: Health check endpoint returning {"status":"healthy"}
+//   - GET /events: Server-Sent Events stream of result changes and scan progress,
+//     used by the dashboard to update in place
+//   - GET /metrics: Prometheus metrics for results, scan cycles, probes and BSR fetches
+//   - GET /api/v1/...: Versioned JSON API for results, services and statistics,
+//     described by the OpenAPI document at /api/v1/openapi.json
+//   - POST /api/v1/scans: Queue an on-demand scan of a cluster, service or pod
+//   - GET /internal/results: All scan results as JSON, used by other replicas
+//     to replicate results
+//   - GET /internal/snapshots?id=: A raw schema snapshot, used by other replicas
 //
-// The server reads scan results from the in-memory store and renders them using
-// Go's html/template package with an embedded template file.
+// The server reads scan results and drift history from the store and renders them
+// using Go's html/template package with embedded template files. Shared styles
+// live in templates/base.html and are parsed into every page.
 //
 // Example usage:
 //
@@ -35,44 +55,63 @@
 package web
 
 import (
-	_ "embed"
+	"embed"
+	"encoding/json"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/uzdada/protodiff/internal/core/domain"
 	"github.com/uzdada/protodiff/internal/core/store"
 )
 
-//go:embed templates/index.html
-var indexTemplate string
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// pages lists the page templates, each parsed together with templates/base.html
+var pages = []string{"index", "service", "pod"}
+
+// InternalResultsPath serves the raw scan results to other replicas
+const InternalResultsPath = "/internal/results"
 
 // Server provides the HTTP server for the dashboard
 type Server struct {
-	store    *store.Store
-	template *template.Template
-	addr     string
+	store     store.Store
+	rescanner Rescanner
+	progress  progressHub
+	metrics   *metrics
+	templates map[string]*template.Template
+	addr      string
 }
 
 // NewServer creates a new web server instance
-func NewServer(store *store.Store, addr string) (*Server, error) {
+func NewServer(store store.Store, addr string) (*Server, error) {
 	// Create template with custom functions
 	funcMap := template.FuncMap{
 		"add": func(a, b int) int {
 			return a + b
 		},
+		"shortDigest": shortDigest,
+		"duration":    formatDuration,
 	}
 
-	tmpl, err := template.New("index").Funcs(funcMap).Parse(indexTemplate)
-	if err != nil {
-		return nil, err
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		tmpl, err := template.New(page+".html").Funcs(funcMap).ParseFS(templateFS, "templates/"+page+".html", "templates/base.html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", page, err)
+		}
+		templates[page] = tmpl
 	}
 
 	return &Server{
-		store:    store,
-		template: tmpl,
-		addr:     addr,
+		store:     store,
+		metrics:   newMetrics(store),
+		templates: templates,
+		addr:      addr,
 	}, nil
 }
 
@@ -86,15 +125,37 @@ type Statistics struct {
 
 // TemplateData represents the data passed to the HTML template
 type TemplateData struct {
-	Results    []*domain.ScanResult
-	Stats      Statistics
-	LastUpdate string
+	// Results are the results selected by the view, in display order
+	Results []*domain.ScanResult
+	Groups  []ResultGroup
+	// GroupHeaders shows a heading row per group
+	GroupHeaders bool
+	View         DashboardView
+	Options      DashboardOptions
+	// AllCount is the number of results before filtering
+	AllCount     int
+	MultiCluster bool
+	Skews        []domain.ClusterSkew
+	Workloads    []domain.WorkloadSummary
+	Images       []domain.ImageRecord
+	Stats        Statistics
+	LastUpdate   string
+	// Rescan shows the on-demand scan buttons
+	Rescan bool
 }
 
 // Start begins serving HTTP requests
 func (s *Server) Start() error {
 	http.HandleFunc("/", s.handleDashboard)
+	http.HandleFunc("/service", s.handleService)
+	http.HandleFunc("/pod", s.handlePod)
+	http.HandleFunc("/schema", s.handleSchema)
 	http.HandleFunc("/health", s.handleHealth)
+	http.HandleFunc("/events", s.handleEvents)
+	http.Handle("/metrics", s.metrics.handler())
+	http.HandleFunc(InternalResultsPath, s.handleInternalResults)
+	http.HandleFunc(InternalSnapshotsPath, s.handleInternalSnapshot)
+	s.registerAPI(http.DefaultServeMux)
 
 	log.Printf("Starting web server on %s", s.addr)
 	return http.ListenAndServe(s.addr, nil)
@@ -102,17 +163,35 @@ func (s *Server) Start() error {
 
 // handleDashboard renders the main dashboard
 func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
-	results := s.store.GetAll()
-	stats := calculateStatistics(results)
+	all := s.store.GetAll()
+	view := parseDashboardView(r.URL.Query())
+	options := dashboardOptions(all)
+	multiCluster := len(options.Clusters) > 1
+	results := view.apply(all)
 
 	data := TemplateData{
-		Results:    results,
-		Stats:      stats,
-		LastUpdate: time.Now().Format("2006-01-02 15:04:05"),
+		Results:      results,
+		Groups:       groupResults(results, view.Group, multiCluster),
+		GroupHeaders: view.Group != groupNone && (view.Group != groupCluster || multiCluster),
+		View:         view,
+		Options:      options,
+		AllCount:     len(all),
+		MultiCluster: multiCluster,
+		Skews:        domain.DetectClusterSkew(all),
+		Workloads:    s.store.GetWorkloads(),
+		Images:       s.store.GetImageHistory(),
+		Stats:        calculateStatistics(results),
+		LastUpdate:   time.Now().Format("2006-01-02 15:04:05"),
+		Rescan:       s.rescanner != nil,
 	}
 
+	s.render(w, "index", data)
+}
+
+// render executes a page template
+func (s *Server) render(w http.ResponseWriter, page string, data interface{}) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := s.template.Execute(w, data); err != nil {
+	if err := s.templates[page].Execute(w, data); err != nil {
 		log.Printf("Error rendering template: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -139,6 +218,23 @@ func calculateStatistics(results []*domain.ScanResult) Statistics {
 	return stats
 }
 
+// groupByCluster groups scan results by cluster name.
+// Groups are sorted by cluster name and results within a group by service and pod name.
+func groupByCluster(results []*domain.ScanResult) []ResultGroup {
+	sorted := append([]*domain.ScanResult(nil), results...)
+	sortDashboard(sorted, sortByName)
+	return groupResults(sorted, groupCluster, false)
+}
+
+// shortDigest abbreviates an image digest or commit ID for display
+func shortDigest(digest string) string {
+	digest = strings.TrimPrefix(digest, "sha256:")
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
 // handleHealth provides a health check endpoint
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -147,3 +243,11 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Error writing health response: %v", err)
 	}
 }
+
+// handleInternalResults returns all stored scan results as JSON
+func (s *Server) handleInternalResults(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.store.GetAll()); err != nil {
+		log.Printf("Error writing results response: %v", err)
+	}
+}
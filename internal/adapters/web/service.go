@@ -0,0 +1,207 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// maxServiceEvents bounds the transitions listed on the service page
+const maxServiceEvents = 200
+
+// defaultWindow is the timeline window shown when none is requested
+const defaultWindow = "7d"
+
+// timelineWindows are the windows offered on the service page
+var timelineWindows = []string{"24h", "7d", "14d"}
+
+// TimelineSegment is a status period drawn on the timeline bar
+type TimelineSegment struct {
+	domain.StatusPeriod
+	// Percent is the share of the window covered by the segment
+	Percent float64
+}
+
+// ServicePageData represents the data passed to the service template
+type ServicePageData struct {
+	ClusterName string
+	ServiceName string
+	// Status is the current aggregated status of the service's pods
+	Status  domain.DiffStatus
+	Results []*domain.ScanResult
+	// Window is the timeline window, starting at Since
+	Window  string
+	Windows []string
+	Since   time.Time
+	// Timeline holds the status periods within the window
+	Timeline  []TimelineSegment
+	OutOfSync time.Duration
+	// DriftingSince is when the current drift started (nil unless MISMATCH)
+	DriftingSince *time.Time
+	DriftingFor   time.Duration
+	// Events are the transitions within the window, newest first
+	Events     []domain.HistoryEvent
+	LastUpdate string
+	// Rescan shows the on-demand scan button
+	Rescan bool
+}
+
+// handleService renders the status timeline and transitions of a service
+func (s *Server) handleService(w http.ResponseWriter, r *http.Request) {
+	clusterName := r.URL.Query().Get("cluster")
+	serviceName := r.URL.Query().Get("name")
+	if serviceName == "" {
+		http.Error(w, "missing service name", http.StatusBadRequest)
+		return
+	}
+
+	window := r.URL.Query().Get("window")
+	length, err := parseWindow(window)
+	if err != nil {
+		window = defaultWindow
+		length, _ = parseWindow(window)
+	}
+
+	now := time.Now()
+	since := now.Add(-length)
+	history := s.store.History(domain.HistoryQuery{
+		ClusterName: clusterName,
+		ServiceName: serviceName,
+	})
+
+	var results []*domain.ScanResult
+	for _, result := range s.store.GetAll() {
+		if result.ServiceName == serviceName && (clusterName == "" || result.ClusterName == clusterName) {
+			results = append(results, result)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].ClusterName != results[j].ClusterName {
+			return results[i].ClusterName < results[j].ClusterName
+		}
+		return results[i].PodName < results[j].PodName
+	})
+
+	periods := domain.ServiceTimeline(history, since, now)
+	data := ServicePageData{
+		ClusterName: clusterName,
+		ServiceName: serviceName,
+		Status:      serviceStatus(results),
+		Results:     results,
+		Window:      window,
+		Windows:     timelineWindows,
+		Since:       since,
+		Timeline:    timelineSegments(periods, length),
+		OutOfSync:   domain.TimeInStatus(periods, domain.StatusMismatch),
+		Events:      eventsSince(history, since),
+		LastUpdate:  now.Format("2006-01-02 15:04:05"),
+		Rescan:      s.rescanner != nil,
+	}
+
+	// The drift may have started before the window, so look at the whole history
+	if all := domain.ServiceTimeline(history, time.Time{}, now); len(all) > 0 {
+		last := all[len(all)-1]
+		if last.Status == domain.StatusMismatch && data.Status == domain.StatusMismatch {
+			data.DriftingSince = &last.Start
+			data.DriftingFor = last.Duration()
+		}
+	}
+
+	s.render(w, "service", data)
+}
+
+// serviceStatus aggregates the current results of a service: MISMATCH if any
+// pod drifted, UNKNOWN if any pod could not be validated, SYNC otherwise
+func serviceStatus(results []*domain.ScanResult) domain.DiffStatus {
+	if len(results) == 0 {
+		return domain.StatusUnknown
+	}
+	status := domain.StatusSync
+	for _, result := range results {
+		switch result.Status {
+		case domain.StatusMismatch:
+			return domain.StatusMismatch
+		case domain.StatusUnknown:
+			status = domain.StatusUnknown
+		}
+	}
+	return status
+}
+
+// timelineSegments sizes status periods relative to the window
+func timelineSegments(periods []domain.StatusPeriod, window time.Duration) []TimelineSegment {
+	segments := make([]TimelineSegment, 0, len(periods))
+	for _, period := range periods {
+		segments = append(segments, TimelineSegment{
+			StatusPeriod: period,
+			Percent:      float64(period.Duration()) / float64(window) * 100,
+		})
+	}
+	return segments
+}
+
+// eventsSince returns the newest-first events not older than since, capped at maxServiceEvents
+func eventsSince(history []domain.HistoryEvent, since time.Time) []domain.HistoryEvent {
+	var events []domain.HistoryEvent
+	for _, event := range history {
+		if event.Timestamp.Before(since) || len(events) == maxServiceEvents {
+			break
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// parseWindow parses a timeline window such as "24h" or "7d"
+func parseWindow(window string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(window, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window %q", window)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q", window)
+	}
+	return d, nil
+}
+
+// formatDuration renders a duration in days, hours and minutes
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
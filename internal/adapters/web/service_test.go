@@ -0,0 +1,105 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"24h", 24 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"", 0, true},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"xd", 0, true},
+		{"week", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWindow(tt.window)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseWindow(%q) = %v, %v, want %v (error %t)", tt.window, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestServiceStatus(t *testing.T) {
+	result := func(status domain.DiffStatus) *domain.ScanResult {
+		return &domain.ScanResult{Status: status}
+	}
+	tests := []struct {
+		name    string
+		results []*domain.ScanResult
+		want    domain.DiffStatus
+	}{
+		{"no pods", nil, domain.StatusUnknown},
+		{"all in sync", []*domain.ScanResult{result(domain.StatusSync), result(domain.StatusSync)}, domain.StatusSync},
+		{"one unknown", []*domain.ScanResult{result(domain.StatusSync), result(domain.StatusUnknown)}, domain.StatusUnknown},
+		{"drift wins", []*domain.ScanResult{result(domain.StatusUnknown), result(domain.StatusMismatch), result(domain.StatusSync)}, domain.StatusMismatch},
+	}
+	for _, tt := range tests {
+		if got := serviceStatus(tt.results); got != tt.want {
+			t.Errorf("serviceStatus(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleService(t *testing.T) {
+	server := newTestServer(t, testResults()...)
+	podLink := regexp.MustCompile(`href="/pod\?cluster=([^&]*)&namespace=[^&]*&pod=([^&"]*)`)
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"/service?cluster=default&name=users", []string{"default/users-a", "default/users-b"}},
+		// Without a cluster, the service's pods in every cluster are listed
+		{"/service?name=users&window=24h", []string{"default/users-a", "default/users-b", "eu%2fwest/users-c"}},
+		{"/service?name=billing", nil},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		server.handleService(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d, want 200: %s", tt.target, w.Code, w.Body)
+		}
+		var pods []string
+		for _, match := range podLink.FindAllStringSubmatch(w.Body.String(), -1) {
+			pods = append(pods, match[1]+"/"+match[2])
+		}
+		if !reflect.DeepEqual(pods, tt.want) {
+			t.Errorf("GET %s: pods = %v, want %v", tt.target, pods, tt.want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	server.handleService(w, httptest.NewRequest(http.MethodGet, "/service?cluster=default", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET /service without a name: status %d, want 400", w.Code)
+	}
+}
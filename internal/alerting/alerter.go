@@ -0,0 +1,275 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerting notifies external systems when pods change status.
+//
+// The scanner hands every status transition (drift, recovery, validation
+// failure) to the Alerter, which delivers it to the configured webhooks from a
+// goroutine of its own, so slow receivers never hold up scanning. Webhooks post
+// JSON (optionally templated), Slack messages, Microsoft Teams cards or
+// Prometheus Alertmanager v2 alerts. Routes pick the webhooks of each alert by
+// namespace, owner, service or cluster.
+//
+// Alerts are deduplicated per service: replicas of a workload drifting the same
+// way, or a drift already reported, produce a single alert, and a service is
+// only reported recovered once all of its pods are in sync. An alert is not
+// repeated within the cool-down window, which quiets services flapping between
+// SYNC and MISMATCH. Failed deliveries are retried with exponential backoff.
+//
+// The Digester emails a daily HTML digest of new, resolved and long-running
+// drifts and unreachable services to recipients per namespace over SMTP.
+package alerting
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// queueSize bounds the alerts waiting for delivery
+const queueSize = 256
+
+// Alerter delivers status transitions to webhooks
+type Alerter struct {
+	store        store.Store
+	webhooks     []*webhook
+	routes       []route
+	dashboardURL string
+	cooldown     time.Duration
+	queue        chan domain.Alert
+	// reevaluate holds a pending check of the services with an outstanding alert
+	reevaluate chan struct{}
+
+	// latest is the last alert delivered per service
+	latest map[string]domain.Alert
+	// sent is when each alert key was last delivered
+	sent map[string]time.Time
+}
+
+// NewAlerter creates an alerter from a validated configuration. The store
+// holds the current results of every pod, from which service status is derived.
+func NewAlerter(config Config, s store.Store) *Alerter {
+	a := &Alerter{
+		store:        s,
+		dashboardURL: config.DashboardURL,
+		cooldown:     config.cooldown(),
+		queue:        make(chan domain.Alert, queueSize),
+		reevaluate:   make(chan struct{}, 1),
+		latest:       make(map[string]domain.Alert),
+		sent:         make(map[string]time.Time),
+	}
+	byName := make(map[string]*webhook, len(config.Webhooks))
+	for _, webhookConfig := range config.Webhooks {
+		w := newWebhook(webhookConfig)
+		a.webhooks = append(a.webhooks, w)
+		byName[webhookConfig.Name] = w
+	}
+	for _, routeConfig := range config.Routes {
+		a.routes = append(a.routes, newRoute(routeConfig, byName))
+	}
+	return a
+}
+
+// Notify queues an alert for delivery without blocking. Alerts are dropped
+// with a warning while the queue is full.
+func (a *Alerter) Notify(alert domain.Alert) {
+	select {
+	case a.queue <- alert:
+	default:
+		log.Printf("Warning: Alert queue is full, dropping %s alert for %s/%s/%s",
+			alert.Kind, alert.Result.ClusterName, alert.Result.PodNamespace, alert.Result.PodName)
+	}
+}
+
+// Reevaluate queues a check of the services whose last alert was a drift or a
+// failure, without blocking. It is called at the end of each scan cycle: pods
+// removed during the cycle, such as drifted pods replaced by a rollout, can
+// leave a service in sync without any pod changing status.
+func (a *Alerter) Reevaluate() {
+	select {
+	case a.reevaluate <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers queued alerts until ctx is cancelled. Alerts firing in
+// Alertmanager are re-sent periodically so they stay active until resolved.
+func (a *Alerter) Run(ctx context.Context) {
+	log.Printf("Alerting enabled with %d webhook(s) and %d route(s), cool-down %s", len(a.webhooks), len(a.routes), a.cooldown)
+
+	ticker := time.NewTicker(alertmanagerResendInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case alert := <-a.queue:
+			a.process(ctx, alert)
+		case <-a.reevaluate:
+			a.recoverServices(ctx)
+			a.resolveFiring(ctx)
+		case <-ticker.C:
+			a.resendFiring(ctx)
+		}
+	}
+}
+
+// resendFiring re-sends the firing alerts of every Alertmanager webhook
+func (a *Alerter) resendFiring(ctx context.Context) {
+	for _, webhook := range a.webhooks {
+		if webhook.format != FormatAlertmanager {
+			continue
+		}
+		if err := webhook.resendFiring(ctx); err != nil {
+			log.Printf("Warning: Failed to re-send firing alerts to webhook %s: %v", webhook.name, err)
+		}
+	}
+}
+
+// resolveFiring resolves the alerts firing in Alertmanager whose service has no
+// drifted or failing pod left, e.g. once a rollout replaced the drifted pods or
+// the service is gone, even when no recovered alert was delivered for it
+func (a *Alerter) resolveFiring(ctx context.Context) {
+	for _, webhook := range a.webhooks {
+		if webhook.format != FormatAlertmanager {
+			continue
+		}
+		err := webhook.resolveFiring(ctx, func(alert alertmanagerAlert) bool {
+			status := domain.StatusMismatch
+			if alert.Labels["alertname"] == alertNameFailed {
+				status = domain.StatusUnknown
+			}
+			service := alert.Labels["cluster"] + "/" + alert.Labels["namespace"] + "/" + alert.Labels["service"]
+			for _, pod := range a.servicePods(service) {
+				if pod.Status == status {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to resolve alerts of webhook %s: %v", webhook.name, err)
+		}
+	}
+}
+
+// process delivers an alert unless it duplicates the last alert of its
+// service or was already sent within the cool-down window. Status is tracked
+// per pod, so a recovered pod only recovers its service once no other pod of
+// the service is drifted or failing.
+func (a *Alerter) process(ctx context.Context, alert domain.Alert) {
+	key := alert.DedupKey()
+	service := serviceKey(alert.Result)
+	now := time.Now()
+
+	if latest, ok := a.latest[service]; ok && latest.DedupKey() == key {
+		return
+	}
+	pods := a.servicePods(service)
+	if alert.Kind == domain.AlertRecovered {
+		if status := serviceStatus(pods); status != domain.StatusSync {
+			log.Printf("Held back recovered alert for %s/%s/%s: service is still %s", alert.Result.ClusterName,
+				alert.Result.PodNamespace, alert.Result.ServiceName, status)
+			return
+		}
+	}
+	if sent, ok := a.sent[key]; ok && now.Sub(sent) < a.cooldown {
+		log.Printf("Suppressed %s alert for %s/%s/%s (cool-down until %s)", alert.Kind,
+			alert.Result.ClusterName, alert.Result.PodNamespace, alert.Result.ServiceName,
+			sent.Add(a.cooldown).Format(time.RFC3339))
+		return
+	}
+
+	a.latest[service] = alert
+	a.sent[key] = now
+	a.pruneSent(now)
+
+	message := newMessage(alert, pods, a.dashboardURL)
+	webhooks := routeMessage(a.routes, a.webhooks, message)
+	if len(webhooks) == 0 {
+		log.Printf("No route for %s alert of %s/%s/%s", alert.Kind, alert.Result.ClusterName, alert.Result.PodNamespace, alert.Result.ServiceName)
+		return
+	}
+	for _, webhook := range webhooks {
+		if !webhook.accepts(alert.Kind) {
+			continue
+		}
+		if err := webhook.send(ctx, message); err != nil {
+			log.Printf("Warning: Failed to deliver %s alert for %s to webhook %s: %v", alert.Kind, alert.Result.ServiceName, webhook.name, err)
+			continue
+		}
+		log.Printf("Delivered %s alert for %s/%s/%s to webhook %s", alert.Kind,
+			alert.Result.ClusterName, alert.Result.PodNamespace, alert.Result.PodName, webhook.name)
+	}
+}
+
+// recoverServices reports the services whose last alert was a drift or a
+// failure recovered once all of their remaining pods are in sync, on behalf of
+// the most recently checked pod. Services with no pods left are forgotten.
+func (a *Alerter) recoverServices(ctx context.Context) {
+	for service, latest := range a.latest {
+		if latest.Kind == domain.AlertRecovered {
+			continue
+		}
+		pods := a.servicePods(service)
+		if len(pods) == 0 {
+			delete(a.latest, service)
+			continue
+		}
+		if serviceStatus(pods) != domain.StatusSync {
+			continue
+		}
+		newest := pods[0]
+		for _, pod := range pods[1:] {
+			if pod.LastChecked.After(newest.LastChecked) {
+				newest = pod
+			}
+		}
+		a.process(ctx, domain.Alert{
+			Kind:      domain.AlertRecovered,
+			From:      latest.Result.Status,
+			Result:    newest,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// pruneSent forgets delivery times that no longer affect the cool-down
+func (a *Alerter) pruneSent(now time.Time) {
+	for key, sent := range a.sent {
+		if now.Sub(sent) >= a.cooldown {
+			delete(a.sent, key)
+		}
+	}
+}
+
+// servicePods returns the current results of a service's pods
+func (a *Alerter) servicePods(service string) []*domain.ScanResult {
+	var pods []*domain.ScanResult
+	for _, result := range a.store.GetAll() {
+		if serviceKey(result) == service {
+			pods = append(pods, result)
+		}
+	}
+	return pods
+}
+
+// serviceKey identifies the service of a result across its pods
+func serviceKey(result *domain.ScanResult) string {
+	return result.ClusterName + "/" + result.PodNamespace + "/" + result.ServiceName
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// receiver records the request bodies posted to it
+type receiver struct {
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+// newReceiver starts an HTTP server recording the bodies posted to it
+func newReceiver(t *testing.T) (*receiver, *httptest.Server) {
+	t.Helper()
+	r := &receiver{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		r.mu.Lock()
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+// take returns the bodies received since the last call
+func (r *receiver) take() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bodies := r.bodies
+	r.bodies = nil
+	return bodies
+}
+
+// takeMessages decodes the default JSON payloads received since the last call
+func (r *receiver) takeMessages(t *testing.T) []Message {
+	t.Helper()
+	var messages []Message
+	for _, body := range r.take() {
+		var message Message
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Fatalf("failed to decode message %s: %v", body, err)
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// podResult builds the result of a pod of the users service in prod
+func podResult(pod string, status domain.DiffStatus) *domain.ScanResult {
+	return &domain.ScanResult{
+		ClusterName:     "default",
+		PodNamespace:    "prod",
+		PodName:         pod,
+		ServiceName:     "users",
+		Status:          status,
+		LiveFingerprint: "live-1",
+		BSRCommit:       "commit-1",
+		LastChecked:     time.Now(),
+	}
+}
+
+// transition stores the new status of a pod and hands the resulting alert to
+// the alerter, like the scanner does
+func transition(t *testing.T, a *Alerter, s store.Store, pod string, status domain.DiffStatus) {
+	t.Helper()
+	current := podResult(pod, status)
+	previous, _ := s.Get(current.Key())
+	s.Set(current)
+	if alert := domain.NewAlert(previous, current); alert != nil {
+		a.process(context.Background(), *alert)
+	}
+}
+
+func TestAlerterRecoversServiceOnlyWhenAllPodsRecovered(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "ops", URL: server.URL}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-b", domain.StatusSync)
+	if got := r.takeMessages(t); len(got) != 0 {
+		t.Fatalf("got %d alerts for pods first seen in sync, want none", len(got))
+	}
+
+	// Both pods drift the same way: one alert for the service
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+	transition(t, a, s, "users-b", domain.StatusMismatch)
+	messages := r.takeMessages(t)
+	if len(messages) != 1 || messages[0].Kind != domain.AlertDrift {
+		t.Fatalf("got %+v, want a single drift alert", messages)
+	}
+	if messages[0].PodCount != 2 || messages[0].MismatchCount != 1 {
+		t.Errorf("drift alert counts %d of %d pods drifted, want 1 of 2", messages[0].MismatchCount, messages[0].PodCount)
+	}
+
+	// users-b still drifts, so the service has not recovered
+	transition(t, a, s, "users-a", domain.StatusSync)
+	if got := r.takeMessages(t); len(got) != 0 {
+		t.Fatalf("got %+v while users-b still drifts, want no alert", got)
+	}
+
+	transition(t, a, s, "users-b", domain.StatusSync)
+	messages = r.takeMessages(t)
+	if len(messages) != 1 || messages[0].Kind != domain.AlertRecovered || messages[0].Pod != "users-b" {
+		t.Fatalf("got %+v, want a single recovered alert for users-b", messages)
+	}
+	if messages[0].MismatchCount != 0 || messages[0].UnknownCount != 0 {
+		t.Errorf("recovered alert counts %d drifted and %d unknown pods, want none", messages[0].MismatchCount, messages[0].UnknownCount)
+	}
+}
+
+func TestAlerterHoldsRecoveryWhileAPodFails(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "ops", URL: server.URL}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+	transition(t, a, s, "users-b", domain.StatusSync)
+	transition(t, a, s, "users-b", domain.StatusUnknown)
+	transition(t, a, s, "users-a", domain.StatusSync)
+
+	var kinds []domain.AlertKind
+	for _, message := range r.takeMessages(t) {
+		kinds = append(kinds, message.Kind)
+	}
+	want := []domain.AlertKind{domain.AlertDrift, domain.AlertFailed}
+	if len(kinds) != len(want) || kinds[0] != want[0] || kinds[1] != want[1] {
+		t.Fatalf("got alerts %v, want %v", kinds, want)
+	}
+
+	transition(t, a, s, "users-b", domain.StatusSync)
+	messages := r.takeMessages(t)
+	if len(messages) != 1 || messages[0].Kind != domain.AlertRecovered {
+		t.Fatalf("got %+v, want a single recovered alert", messages)
+	}
+}
+
+func TestAlerterRecoversServiceWhenDriftedPodsAreReplaced(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "ops", URL: server.URL}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+	transition(t, a, s, "users-b", domain.StatusMismatch)
+	if got := r.takeMessages(t); len(got) != 1 {
+		t.Fatalf("got %d alerts, want a single drift alert", len(got))
+	}
+
+	// A rollout starts new pods in sync; the drifted ones are still around
+	transition(t, a, s, "users-c", domain.StatusSync)
+	transition(t, a, s, "users-d", domain.StatusSync)
+	a.recoverServices(context.Background())
+	if got := r.takeMessages(t); len(got) != 0 {
+		t.Fatalf("got %+v while drifted pods remain, want no alert", got)
+	}
+
+	// The drifted pods are gone, without any pod changing status
+	s.Delete(podResult("users-a", domain.StatusMismatch).Key())
+	s.Delete(podResult("users-b", domain.StatusMismatch).Key())
+	a.recoverServices(context.Background())
+	messages := r.takeMessages(t)
+	if len(messages) != 1 || messages[0].Kind != domain.AlertRecovered {
+		t.Fatalf("got %+v, want a single recovered alert", messages)
+	}
+	if messages[0].From != domain.StatusMismatch || messages[0].PodCount != 2 || messages[0].MismatchCount != 0 {
+		t.Errorf("recovered alert from %s with %d of %d pods drifted, want from MISMATCH with 0 of 2",
+			messages[0].From, messages[0].MismatchCount, messages[0].PodCount)
+	}
+
+	// The service is reported recovered only once
+	a.recoverServices(context.Background())
+	if got := r.takeMessages(t); len(got) != 0 {
+		t.Fatalf("got %+v after the recovery was reported, want no alert", got)
+	}
+}
+
+func TestAlerterForgetsServicesWithoutPods(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "ops", URL: server.URL}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+	r.take()
+	s.Delete(podResult("users-a", domain.StatusMismatch).Key())
+	a.recoverServices(context.Background())
+
+	if got := r.takeMessages(t); len(got) != 0 {
+		t.Errorf("got %+v for a service without pods, want no alert", got)
+	}
+	if len(a.latest) != 0 {
+		t.Errorf("latest alerts = %v, want the service forgotten", a.latest)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultCooldown is how long an alert is not repeated after it was sent
+	defaultCooldown = 30 * time.Minute
+	// defaultRetries is how often a failed webhook delivery is retried
+	defaultRetries = 3
+	// defaultWebhookTimeout bounds a single webhook request
+	defaultWebhookTimeout = 10 * time.Second
+	// defaultDigestAt is the time of day the digest is sent
+	defaultDigestAt = "09:00"
+	// defaultDigestSubject starts the subject of digest emails
+	defaultDigestSubject = "ProtoDiff daily digest"
+	// defaultSMTPPort is the SMTP submission port
+	defaultSMTPPort = 587
+)
+
+// Config is the alerting configuration, read from the file named by ALERTS_CONFIG:
+//
+//	dashboardURL: https://protodiff.example.com
+//	cooldown: 30m
+//	webhooks:
+//	  - name: ops
+//	    url: https://hooks.example.com/protodiff
+//	    headers:
+//	      Authorization: Bearer secret
+//	    kinds: [drift, recovered]
+//	    template: |
+//	      {"text": {{json .Title}}, "link": {{json .DashboardURL}}}
+//	  - name: payments
+//	    url: https://hooks.slack.com/services/T000/B000/XXXX
+//	    format: slack
+//	routes:
+//	  - namespaces: [payments]
+//	    webhooks: [payments]
+//	  - webhooks: [ops]
+//	digest:
+//	  at: "09:00"
+//	  timezone: Europe/Berlin
+//	  smtp:
+//	    host: smtp.example.com
+//	    username: protodiff
+//	    passwordFile: /etc/protodiff/smtp-password
+//	    from: ProtoDiff <protodiff@example.com>
+//	  recipients:
+//	    - namespaces: [payments]
+//	      to: [payments-leads@example.com]
+//	    - to: [platform-leads@example.com]
+type Config struct {
+	// DashboardURL is the external URL of the dashboard, used for links in alerts
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	// Cooldown suppresses an alert that was already sent within the window,
+	// e.g. a service flapping between SYNC and MISMATCH (default 30m, "0s" disables)
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+	Webhooks []WebhookConfig  `json:"webhooks"`
+	// Routes pick the webhooks of each alert; without routes every webhook gets every alert
+	Routes []RouteConfig `json:"routes,omitempty"`
+	// Digest sends a daily email summary of the drift status
+	Digest *DigestConfig `json:"digest,omitempty"`
+}
+
+// DigestConfig schedules a daily HTML email digest
+type DigestConfig struct {
+	// At is the time of day the digest is sent, as HH:MM (default 09:00)
+	At string `json:"at,omitempty"`
+	// Timezone is the IANA time zone of At (default UTC)
+	Timezone string `json:"timezone,omitempty"`
+	// Subject starts the subject line (default "ProtoDiff daily digest")
+	Subject string     `json:"subject,omitempty"`
+	SMTP    SMTPConfig `json:"smtp"`
+	// Recipients get a digest of their namespaces each
+	Recipients []RecipientConfig `json:"recipients"`
+}
+
+// SMTPConfig configures the server sending digest emails
+type SMTPConfig struct {
+	Host string `json:"host"`
+	// Port is the server port (default 587)
+	Port     int    `json:"port,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// PasswordFile is read before each delivery, e.g. a mounted Secret
+	PasswordFile string `json:"passwordFile,omitempty"`
+	// From is the sender, e.g. "ProtoDiff <protodiff@example.com>"
+	From string `json:"from"`
+	// TLS connects with implicit TLS (usually port 465) instead of upgrading
+	// the connection with STARTTLS when the server offers it
+	TLS bool `json:"tls,omitempty"`
+}
+
+// RecipientConfig sends the digest of some namespaces to a set of addresses
+type RecipientConfig struct {
+	// Namespaces limits the digest to these namespaces (default: all)
+	Namespaces []string `json:"namespaces,omitempty"`
+	To         []string `json:"to"`
+}
+
+// RouteConfig sends the alerts it matches to a set of webhooks. Routes are
+// tried in order and the first match wins, unless it sets continue. Empty
+// match fields match everything, so a route with only webhooks is a catch-all.
+type RouteConfig struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Owners match the protodiff.io/owner annotation of the service's pods
+	Owners   []string `json:"owners,omitempty"`
+	Services []string `json:"services,omitempty"`
+	Clusters []string `json:"clusters,omitempty"`
+	// Webhooks are the names of the webhooks receiving the matched alerts
+	Webhooks []string `json:"webhooks"`
+	// Continue keeps trying the following routes after a match
+	Continue bool `json:"continue,omitempty"`
+}
+
+// WebhookConfig configures a JSON webhook receiving alerts
+type WebhookConfig struct {
+	// Name identifies the webhook in logs (defaults to its host)
+	Name    string            `json:"name,omitempty"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Kinds limits the alerts sent to the webhook (default: all kinds)
+	Kinds []domain.AlertKind `json:"kinds,omitempty"`
+	// Format is the payload format: json, slack, teams or alertmanager (default json)
+	Format string `json:"format,omitempty"`
+	// Template renders the JSON payload from a Message (json format only,
+	// default: the Message as JSON)
+	Template string `json:"template,omitempty"`
+	// Retries is how often a failed delivery is retried (default 3)
+	Retries *int `json:"retries,omitempty"`
+	// Timeout bounds each delivery attempt (default 10s)
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// LoadConfig reads and validates an alerting configuration file
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read alerting config: %w", err)
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to parse alerting config %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid alerting config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// validate checks the configuration for mistakes that would only show when an alert fires
+func (c Config) validate() error {
+	if c.DashboardURL != "" {
+		if _, err := url.Parse(c.DashboardURL); err != nil {
+			return fmt.Errorf("invalid dashboardURL: %w", err)
+		}
+	}
+	names := make(map[string]bool, len(c.Webhooks))
+	for i, webhook := range c.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("webhook %d has no url", i+1)
+		}
+		if webhook.Name != "" {
+			if names[webhook.Name] {
+				return fmt.Errorf("duplicate webhook name %q", webhook.Name)
+			}
+			names[webhook.Name] = true
+		}
+		switch webhook.Format {
+		case "", FormatJSON:
+		case FormatSlack, FormatTeams, FormatAlertmanager:
+			if webhook.Template != "" {
+				return fmt.Errorf("webhook %d: templates are only supported with the json format", i+1)
+			}
+			if webhook.Format == FormatAlertmanager && len(webhook.Kinds) > 0 {
+				return fmt.Errorf("webhook %d: alertmanager webhooks receive all kinds, so recoveries resolve their alerts", i+1)
+			}
+		default:
+			return fmt.Errorf("webhook %d: unknown format %q (expected json, slack, teams or alertmanager)", i+1, webhook.Format)
+		}
+		if _, err := url.ParseRequestURI(webhook.URL); err != nil {
+			return fmt.Errorf("webhook %d: invalid url: %w", i+1, err)
+		}
+		for _, kind := range webhook.Kinds {
+			switch kind {
+			case domain.AlertDrift, domain.AlertRecovered, domain.AlertFailed:
+			default:
+				return fmt.Errorf("webhook %d: unknown alert kind %q (expected drift, recovered or failed)", i+1, kind)
+			}
+		}
+		if webhook.Template != "" {
+			if _, err := parseTemplate(webhook.Template); err != nil {
+				return fmt.Errorf("webhook %d: %w", i+1, err)
+			}
+		}
+		if webhook.Retries != nil && *webhook.Retries < 0 {
+			return fmt.Errorf("webhook %d: retries must not be negative", i+1)
+		}
+	}
+	for i, route := range c.Routes {
+		if len(route.Webhooks) == 0 {
+			return fmt.Errorf("route %d has no webhooks", i+1)
+		}
+		for _, name := range route.Webhooks {
+			if !names[name] {
+				return fmt.Errorf("route %d: unknown webhook %q", i+1, name)
+			}
+		}
+	}
+	if c.Digest != nil {
+		if err := c.Digest.validate(); err != nil {
+			return fmt.Errorf("digest: %w", err)
+		}
+	}
+	return nil
+}
+
+// validate checks the digest schedule, SMTP server and recipients
+func (c DigestConfig) validate() error {
+	if _, err := time.Parse("15:04", c.at()); err != nil {
+		return fmt.Errorf("invalid at %q (expected HH:MM)", c.At)
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	if c.SMTP.Host == "" {
+		return fmt.Errorf("smtp has no host")
+	}
+	if c.SMTP.Password != "" && c.SMTP.PasswordFile != "" {
+		return fmt.Errorf("smtp sets both password and passwordFile")
+	}
+	if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+		return fmt.Errorf("smtp: invalid from %q: %w", c.SMTP.From, err)
+	}
+	if len(c.Recipients) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	for i, recipient := range c.Recipients {
+		if len(recipient.To) == 0 {
+			return fmt.Errorf("recipient %d has no addresses", i+1)
+		}
+		for _, address := range recipient.To {
+			if _, err := mail.ParseAddress(address); err != nil {
+				return fmt.Errorf("recipient %d: invalid address %q: %w", i+1, address, err)
+			}
+		}
+	}
+	return nil
+}
+
+// at returns the configured time of day
+func (c DigestConfig) at() string {
+	if c.At == "" {
+		return defaultDigestAt
+	}
+	return c.At
+}
+
+// cooldown returns the configured cooldown window
+func (c Config) cooldown() time.Duration {
+	if c.Cooldown == nil {
+		return defaultCooldown
+	}
+	return c.Cooldown.Duration
+}
+
+// parseTemplate parses a payload template
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
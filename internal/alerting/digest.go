@@ -0,0 +1,403 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// digestSectionLimit caps the services listed per digest section
+const digestSectionLimit = 25
+
+//go:embed templates/digest.html
+var digestFS embed.FS
+
+// digestTemplate renders the HTML body of digest emails
+var digestTemplate = template.Must(template.New("digest.html").Funcs(template.FuncMap{
+	"duration": formatDuration,
+	"section":  newSectionView,
+}).ParseFS(digestFS, "templates/digest.html"))
+
+// Digest summarizes the drift status of some namespaces over a period
+type Digest struct {
+	Since time.Time
+	Until time.Time
+	// Namespaces are the namespaces covered (empty means all)
+	Namespaces []string
+	// Sync, Mismatch and Unknown count the services currently in each status
+	Sync     int
+	Mismatch int
+	Unknown  int
+	// NewDrifts are the drifts that started within the period
+	NewDrifts DigestSection
+	// ResolvedDrifts are the drifts that went back to SYNC within the period
+	ResolvedDrifts DigestSection
+	// LongestDrifts are the services still drifting, longest first
+	LongestDrifts DigestSection
+	// Unreachable are the services that currently can't be validated, longest first
+	Unreachable  DigestSection
+	DashboardURL string
+}
+
+// DigestSection lists services of a digest, up to digestSectionLimit
+type DigestSection struct {
+	Entries []DigestEntry
+	// More counts the services left out of the list
+	More int
+}
+
+// sectionView is the data of the section template
+type sectionView struct {
+	Title   string
+	Empty   string
+	Color   string
+	AtLabel string
+	Section DigestSection
+}
+
+// newSectionView bundles a section with its headings for the section template
+func newSectionView(title, empty, color, atLabel string, section DigestSection) sectionView {
+	return sectionView{Title: title, Empty: empty, Color: color, AtLabel: atLabel, Section: section}
+}
+
+// DigestEntry is a service listed in a digest
+type DigestEntry struct {
+	Cluster   string
+	Namespace string
+	Service   string
+	Owner     string
+	Module    string
+	// At is when the drift or unreachability started, or when the drift was resolved
+	At time.Time
+	// Duration is how long the drift lasted, or the service has been drifting or unreachable
+	Duration time.Duration
+	// Ongoing marks a new drift that has not been resolved yet
+	Ongoing bool
+	// Message is the validation error of an unreachable service
+	Message string
+	// URL links to the service page ("" without a configured dashboard URL)
+	URL string
+}
+
+// Digester emails a daily digest of the drift status to each recipient group
+type Digester struct {
+	config       DigestConfig
+	store        store.Store
+	dashboardURL string
+	location     *time.Location
+	gate         func() bool
+}
+
+// NewDigester creates a digester from a validated configuration with a digest
+func NewDigester(config Config, s store.Store) *Digester {
+	location, _ := time.LoadLocation(config.Digest.Timezone)
+	return &Digester{
+		config:       *config.Digest,
+		store:        s,
+		dashboardURL: config.DashboardURL,
+		location:     location,
+	}
+}
+
+// SetGate makes the digester send only while gate returns true, so a single
+// replica sends the digest. Must be called before Run.
+func (d *Digester) SetGate(gate func() bool) {
+	d.gate = gate
+}
+
+// Run sends the digest every day at the configured time until ctx is
+// cancelled. Each digest covers the day since the previous one.
+func (d *Digester) Run(ctx context.Context) {
+	log.Printf("Email digest enabled for %d recipient group(s), sent daily at %s %s",
+		len(d.config.Recipients), d.config.at(), d.location)
+
+	for {
+		next := d.nextRun(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if d.gate != nil && !d.gate() {
+			log.Println("Skipped email digest, another replica sends it")
+			continue
+		}
+		if err := d.Send(ctx, next.AddDate(0, 0, -1), next); err != nil {
+			log.Printf("Warning: Failed to send email digest: %v", err)
+		}
+	}
+}
+
+// nextRun returns the next time of day the digest is due after now
+func (d *Digester) nextRun(now time.Time) time.Time {
+	at, _ := time.Parse("15:04", d.config.at())
+	now = now.In(d.location)
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, d.location)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Send emails the digest of the period between since and until to every
+// recipient group, and returns the errors of the groups that failed
+func (d *Digester) Send(ctx context.Context, since, until time.Time) error {
+	from, err := mail.ParseAddress(d.config.SMTP.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	subject := d.config.Subject
+	if subject == "" {
+		subject = defaultDigestSubject
+	}
+
+	var errs []error
+	for i, recipient := range d.config.Recipients {
+		digest := d.Build(recipient.Namespaces, since, until)
+
+		var body bytes.Buffer
+		if err := digestTemplate.Execute(&body, digest); err != nil {
+			errs = append(errs, fmt.Errorf("recipient %d: failed to render digest: %w", i+1, err))
+			continue
+		}
+		line := fmt.Sprintf("%s: %d drifting, %d new, %d resolved, %d unreachable", subject,
+			digest.Mismatch, len(digest.NewDrifts.Entries)+digest.NewDrifts.More,
+			len(digest.ResolvedDrifts.Entries)+digest.ResolvedDrifts.More, digest.Unknown)
+		message, err := composeMail(from.String(), recipient.To, line, body.Bytes(), until)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recipient %d: failed to compose email: %w", i+1, err))
+			continue
+		}
+		if err := d.config.SMTP.sendMail(ctx, recipient.To, message); err != nil {
+			errs = append(errs, fmt.Errorf("recipient %d: %w", i+1, err))
+			continue
+		}
+		log.Printf("Sent email digest to %s", strings.Join(recipient.To, ", "))
+	}
+	return errors.Join(errs...)
+}
+
+// digestService collects the current results of a service
+type digestService struct {
+	cluster   string
+	namespace string
+	name      string
+	results   []*domain.ScanResult
+}
+
+// Build aggregates the store into the digest of the namespaces (all when
+// empty) for the period between since and until
+func (d *Digester) Build(namespaces []string, since, until time.Time) Digest {
+	covered := func(namespace string) bool {
+		if len(namespaces) == 0 {
+			return true
+		}
+		for _, ns := range namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Services with current results, and services that changed within the
+	// period but have since been removed
+	services := make(map[string]*digestService)
+	service := func(cluster, namespace, name string) *digestService {
+		key := cluster + "/" + namespace + "/" + name
+		if services[key] == nil {
+			services[key] = &digestService{cluster: cluster, namespace: namespace, name: name}
+		}
+		return services[key]
+	}
+	for _, result := range d.store.GetAll() {
+		if covered(result.PodNamespace) {
+			s := service(result.ClusterName, result.PodNamespace, result.ServiceName)
+			s.results = append(s.results, result)
+		}
+	}
+	for _, event := range d.store.History(domain.HistoryQuery{Since: since}) {
+		if covered(event.PodNamespace) && !event.Timestamp.After(until) {
+			service(event.ClusterName, event.PodNamespace, event.ServiceName)
+		}
+	}
+
+	digest := Digest{
+		Since:        since,
+		Until:        until,
+		Namespaces:   namespaces,
+		DashboardURL: d.dashboardURL,
+	}
+	var newDrifts, resolved, drifting, unreachable []DigestEntry
+	for _, s := range services {
+		history := d.store.History(domain.HistoryQuery{
+			ClusterName: s.cluster,
+			Namespace:   s.namespace,
+			ServiceName: s.name,
+		})
+		periods := domain.ServiceTimeline(history, time.Time{}, until)
+		entry := d.entry(s)
+
+		for i, period := range periods {
+			if period.Status != domain.StatusMismatch {
+				continue
+			}
+			if !period.Start.Before(since) {
+				drift := entry
+				drift.At = period.Start
+				drift.Duration = period.Duration()
+				drift.Ongoing = i == len(periods)-1
+				newDrifts = append(newDrifts, drift)
+			}
+			if i+1 < len(periods) && periods[i+1].Status == domain.StatusSync && !period.End.Before(since) {
+				fix := entry
+				fix.At = period.End
+				fix.Duration = period.Duration()
+				resolved = append(resolved, fix)
+			}
+		}
+
+		if len(s.results) == 0 {
+			continue
+		}
+		status := serviceStatus(s.results)
+		current := entry
+		if n := len(periods); n > 0 && periods[n-1].Status == status {
+			current.At = periods[n-1].Start
+			current.Duration = periods[n-1].Duration()
+		}
+		switch status {
+		case domain.StatusSync:
+			digest.Sync++
+		case domain.StatusMismatch:
+			digest.Mismatch++
+			drifting = append(drifting, current)
+		default:
+			digest.Unknown++
+			for _, result := range s.results {
+				if result.Status == domain.StatusUnknown {
+					current.Message = result.Message
+					break
+				}
+			}
+			unreachable = append(unreachable, current)
+		}
+	}
+
+	location := until.Location()
+	digest.NewDrifts = newDigestSection(newDrifts, location)
+	digest.ResolvedDrifts = newDigestSection(resolved, location)
+	digest.LongestDrifts = newDigestSection(drifting, location)
+	digest.Unreachable = newDigestSection(unreachable, location)
+	return digest
+}
+
+// entry describes a service without period details
+func (d *Digester) entry(s *digestService) DigestEntry {
+	entry := DigestEntry{
+		Cluster:   s.cluster,
+		Namespace: s.namespace,
+		Service:   s.name,
+	}
+	for _, result := range s.results {
+		if entry.Owner == "" {
+			entry.Owner = result.Owner
+		}
+		if entry.Module == "" {
+			entry.Module = result.BSRModule
+		}
+	}
+	if d.dashboardURL != "" {
+		query := url.Values{}
+		query.Set("cluster", s.cluster)
+		query.Set("name", s.name)
+		entry.URL = strings.TrimSuffix(d.dashboardURL, "/") + "/service?" + query.Encode()
+	}
+	return entry
+}
+
+// newDigestSection orders entries oldest first, shows their times in location
+// and caps them at digestSectionLimit
+func newDigestSection(entries []DigestEntry, location *time.Location) DigestSection {
+	for i := range entries {
+		if !entries[i].At.IsZero() {
+			entries[i].At = entries[i].At.In(location)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.Before(entries[j].At)
+		}
+		return entries[i].Cluster+"/"+entries[i].Namespace+"/"+entries[i].Service <
+			entries[j].Cluster+"/"+entries[j].Namespace+"/"+entries[j].Service
+	})
+	section := DigestSection{Entries: entries}
+	if len(entries) > digestSectionLimit {
+		section.Entries = entries[:digestSectionLimit]
+		section.More = len(entries) - digestSectionLimit
+	}
+	return section
+}
+
+// serviceStatus aggregates the current results of a service: MISMATCH if any
+// pod drifted, UNKNOWN if any pod could not be validated, SYNC otherwise
+func serviceStatus(results []*domain.ScanResult) domain.DiffStatus {
+	status := domain.StatusSync
+	for _, result := range results {
+		switch result.Status {
+		case domain.StatusMismatch:
+			return domain.StatusMismatch
+		case domain.StatusUnknown:
+			status = domain.StatusUnknown
+		}
+	}
+	return status
+}
+
+// formatDuration renders a duration in days, hours and minutes
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
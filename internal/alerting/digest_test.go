@@ -0,0 +1,305 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// smtpDelivery is an email received by the SMTP stub
+type smtpDelivery struct {
+	from string
+	to   []string
+	data string
+}
+
+// smtpStub is a minimal SMTP server recording the emails delivered to it
+type smtpStub struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	deliveries []smtpDelivery
+}
+
+// newSMTPStub starts an SMTP server on a local port
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &smtpStub{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// port returns the port the stub listens on
+func (s *smtpStub) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// serve speaks just enough SMTP for net/smtp to deliver a message
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP stub")
+
+	var delivery smtpDelivery
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			delivery = smtpDelivery{from: smtpAddress(line)}
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			delivery.to = append(delivery.to, smtpAddress(line))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			delivery.data = string(data)
+			s.mu.Lock()
+			s.deliveries = append(s.deliveries, delivery)
+			s.mu.Unlock()
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// received returns the emails delivered so far
+func (s *smtpStub) received() []smtpDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpDelivery(nil), s.deliveries...)
+}
+
+// smtpAddress extracts the address of a MAIL FROM or RCPT TO command
+func smtpAddress(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+// digestStore records a day of status changes across three services
+func digestStore(t *testing.T, now time.Time) store.Store {
+	t.Helper()
+	s := store.NewMemory(store.Options{})
+	set := func(namespace, service string, status domain.DiffStatus, message string, at time.Time) {
+		s.Set(&domain.ScanResult{
+			ClusterName:  "default",
+			PodNamespace: namespace,
+			PodName:      service + "-0",
+			ServiceName:  service,
+			Owner:        "team-" + service,
+			Status:       status,
+			Message:      message,
+			LastChecked:  at,
+		})
+	}
+
+	// orders starts drifting within the day
+	set("prod", "orders", domain.StatusSync, "", now.Add(-30*time.Hour))
+	set("prod", "orders", domain.StatusMismatch, "field removed", now.Add(-2*time.Hour))
+	// users drifted before the day and is fixed within it
+	set("prod", "users", domain.StatusMismatch, "field renamed", now.Add(-30*time.Hour))
+	set("prod", "users", domain.StatusSync, "", now.Add(-time.Hour))
+	// billing cannot be validated
+	set("staging", "billing", domain.StatusUnknown, "connection refused", now.Add(-3*time.Hour))
+	return s
+}
+
+func TestDigesterBuild(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	since := now.Add(-24 * time.Hour)
+	d := NewDigester(Config{
+		DashboardURL: "https://protodiff.example.com",
+		Digest:       &DigestConfig{},
+	}, digestStore(t, now))
+
+	services := func(section DigestSection) []string {
+		var names []string
+		for _, entry := range section.Entries {
+			names = append(names, entry.Service)
+		}
+		return names
+	}
+
+	prod := d.Build([]string{"prod"}, since, now)
+	if prod.Sync != 1 || prod.Mismatch != 1 || prod.Unknown != 0 {
+		t.Errorf("prod counts = %d sync, %d drifting, %d unknown, want 1, 1, 0", prod.Sync, prod.Mismatch, prod.Unknown)
+	}
+	if got := services(prod.NewDrifts); len(got) != 1 || got[0] != "orders" {
+		t.Errorf("new drifts = %v, want [orders]", got)
+	} else if drift := prod.NewDrifts.Entries[0]; !drift.Ongoing || !drift.At.Equal(now.Add(-2*time.Hour)) || drift.Owner != "team-orders" {
+		t.Errorf("new drift = %+v, want an ongoing drift of team-orders since 2h ago", drift)
+	}
+	if got := services(prod.ResolvedDrifts); len(got) != 1 || got[0] != "users" {
+		t.Errorf("resolved drifts = %v, want [users]", got)
+	} else if fix := prod.ResolvedDrifts.Entries[0]; fix.Duration != 29*time.Hour {
+		t.Errorf("resolved drift lasted %s, want 29h", fix.Duration)
+	}
+	if got := services(prod.LongestDrifts); len(got) != 1 || got[0] != "orders" {
+		t.Errorf("longest drifts = %v, want [orders]", got)
+	}
+	if got := services(prod.Unreachable); len(got) != 0 {
+		t.Errorf("unreachable = %v, want none in prod", got)
+	}
+
+	all := d.Build(nil, since, now)
+	if all.Sync != 1 || all.Mismatch != 1 || all.Unknown != 1 {
+		t.Errorf("counts = %d sync, %d drifting, %d unknown, want 1, 1, 1", all.Sync, all.Mismatch, all.Unknown)
+	}
+	if got := services(all.Unreachable); len(got) != 1 || got[0] != "billing" {
+		t.Errorf("unreachable = %v, want [billing]", got)
+	} else if entry := all.Unreachable.Entries[0]; entry.Message != "connection refused" ||
+		!strings.HasPrefix(entry.URL, "https://protodiff.example.com/service?") {
+		t.Errorf("unreachable entry = %+v, want its error and a dashboard link", entry)
+	}
+}
+
+func TestDigesterSend(t *testing.T) {
+	stub := newSMTPStub(t)
+	now := time.Now().UTC().Truncate(time.Minute)
+	d := NewDigester(Config{Digest: &DigestConfig{
+		SMTP: SMTPConfig{
+			Host: "127.0.0.1",
+			Port: stub.port(),
+			From: "ProtoDiff <protodiff@example.com>",
+		},
+		Recipients: []RecipientConfig{
+			{Namespaces: []string{"prod"}, To: []string{"prod-team@example.com"}},
+			{To: []string{"Platform <platform@example.com>", "lead@example.com"}},
+		},
+	}}, digestStore(t, now))
+
+	if err := d.Send(context.Background(), now.Add(-24*time.Hour), now); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	deliveries := stub.received()
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d emails, want 2", len(deliveries))
+	}
+	tests := []struct {
+		to          []string
+		subject     string
+		contains    []string
+		notContains []string
+	}{
+		{
+			to:          []string{"prod-team@example.com"},
+			subject:     "ProtoDiff daily digest: 1 drifting, 1 new, 1 resolved, 0 unreachable",
+			contains:    []string{"orders", "users", "namespaces prod", "owner: team-orders"},
+			notContains: []string{"billing"},
+		},
+		{
+			to:       []string{"platform@example.com", "lead@example.com"},
+			subject:  "ProtoDiff daily digest: 1 drifting, 1 new, 1 resolved, 1 unreachable",
+			contains: []string{"orders", "users", "billing", "connection refused", "all namespaces"},
+		},
+	}
+	for i, tt := range tests {
+		delivery := deliveries[i]
+		if delivery.from != "protodiff@example.com" {
+			t.Errorf("email %d: sender = %s, want protodiff@example.com", i, delivery.from)
+		}
+		if strings.Join(delivery.to, ",") != strings.Join(tt.to, ",") {
+			t.Errorf("email %d: recipients = %v, want %v", i, delivery.to, tt.to)
+		}
+
+		message, err := mail.ReadMessage(strings.NewReader(delivery.data))
+		if err != nil {
+			t.Fatalf("email %d: failed to parse: %v", i, err)
+		}
+		if got := message.Header.Get("Subject"); got != tt.subject {
+			t.Errorf("email %d: subject = %q, want %q", i, got, tt.subject)
+		}
+		if got := message.Header.Get("Content-Type"); got != "text/html; charset=UTF-8" {
+			t.Errorf("email %d: content type = %q, want HTML", i, got)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(bufio.NewReader(message.Body)))
+		if err != nil {
+			t.Fatalf("email %d: failed to decode body: %v", i, err)
+		}
+		html := string(body)
+		if !strings.HasPrefix(strings.TrimSpace(html), "<!DOCTYPE html>") {
+			t.Errorf("email %d: body is not an HTML document", i)
+		}
+		for _, want := range tt.contains {
+			if !strings.Contains(html, want) {
+				t.Errorf("email %d: body does not contain %q", i, want)
+			}
+		}
+		for _, unwanted := range tt.notContains {
+			if strings.Contains(html, unwanted) {
+				t.Errorf("email %d: body contains %q", i, unwanted)
+			}
+		}
+	}
+}
+
+func TestDigesterSendReportsRejectedRecipient(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	d := NewDigester(Config{Digest: &DigestConfig{
+		SMTP: SMTPConfig{Host: "127.0.0.1", Port: newSMTPStub(t).port(), From: "protodiff@example.com"},
+		Recipients: []RecipientConfig{
+			{To: []string{"not an address"}},
+		},
+	}}, digestStore(t, now))
+
+	err := d.Send(context.Background(), now.Add(-24*time.Hour), now)
+	if err == nil || !strings.Contains(err.Error(), "recipient 1") {
+		t.Errorf("Send() error = %v, want an error for recipient 1", err)
+	}
+}
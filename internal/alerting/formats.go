@@ -0,0 +1,265 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Webhook payload formats
+const (
+	// FormatJSON posts the Message as JSON, or the rendered template
+	FormatJSON = "json"
+	// FormatSlack posts a Slack incoming-webhook message with blocks
+	FormatSlack = "slack"
+	// FormatTeams posts a Microsoft Teams message with an Adaptive Card
+	FormatTeams = "teams"
+	// FormatAlertmanager posts to the Prometheus Alertmanager v2 /api/v2/alerts endpoint
+	FormatAlertmanager = "alertmanager"
+)
+
+// Alertmanager alert names, one per alerting condition of a service
+const (
+	alertNameDrift  = "ProtoDiffSchemaDrift"
+	alertNameFailed = "ProtoDiffValidationFailed"
+)
+
+// maxSummaryLength keeps summaries within the text limits of Slack and Teams
+const maxSummaryLength = 2000
+
+// statusEmoji marks a message with the traffic light of its status
+var statusEmoji = map[domain.DiffStatus]string{
+	domain.StatusSync:     "🟢",
+	domain.StatusMismatch: "🔴",
+	domain.StatusUnknown:  "🟡",
+}
+
+// messageFacts lists the labelled details shown in chat messages
+func messageFacts(message Message) [][2]string {
+	facts := [][2]string{
+		{"Service", message.Service},
+		{"Namespace", message.Namespace},
+		{"Pod", message.Pod},
+		{"Cluster", message.Cluster},
+		{"Status", fmt.Sprintf("%s → %s", message.From, message.Status)},
+	}
+	if message.Module != "" {
+		module := message.Module
+		if message.Commit != "" {
+			module += " @ " + shortCommit(message.Commit)
+		}
+		facts = append(facts, [2]string{"Module", module})
+	}
+	if message.Owner != "" {
+		facts = append(facts, [2]string{"Owner", message.Owner})
+	}
+	return facts
+}
+
+// slackPayload renders a Slack incoming-webhook message
+func slackPayload(message Message) interface{} {
+	fields := []map[string]string{}
+	for _, fact := range messageFacts(message) {
+		fields = append(fields, map[string]string{
+			"type": "mrkdwn",
+			"text": fmt.Sprintf("*%s*\n%s", fact[0], slackEscape(fact[1])),
+		})
+	}
+
+	blocks := []interface{}{
+		map[string]interface{}{
+			"type": "header",
+			"text": map[string]string{"type": "plain_text", "text": truncate(statusEmoji[message.Status]+" "+message.Title, 150)},
+		},
+		map[string]interface{}{"type": "section", "fields": fields},
+	}
+	if message.Summary != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": "```" + slackEscape(truncate(message.Summary, maxSummaryLength)) + "```"},
+		})
+	}
+	if message.DashboardURL != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []interface{}{map[string]interface{}{
+				"type": "button",
+				"text": map[string]string{"type": "plain_text", "text": "Open in ProtoDiff"},
+				"url":  message.DashboardURL,
+			}},
+		})
+	}
+
+	return map[string]interface{}{
+		// text is the notification fallback
+		"text":   message.Title,
+		"blocks": blocks,
+	}
+}
+
+// slackEscape escapes the characters Slack treats as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// teamsPayload renders a Microsoft Teams message carrying an Adaptive Card,
+// accepted by incoming webhooks and Workflows
+func teamsPayload(message Message) interface{} {
+	color := "Good"
+	switch message.Status {
+	case domain.StatusMismatch:
+		color = "Attention"
+	case domain.StatusUnknown:
+		color = "Warning"
+	}
+
+	facts := []map[string]string{}
+	for _, fact := range messageFacts(message) {
+		facts = append(facts, map[string]string{"title": fact[0], "value": fact[1]})
+	}
+
+	body := []interface{}{
+		map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   message.Title,
+			"size":   "Large",
+			"weight": "Bolder",
+			"color":  color,
+			"wrap":   true,
+		},
+		map[string]interface{}{"type": "FactSet", "facts": facts},
+	}
+	if message.Summary != "" {
+		body = append(body, map[string]interface{}{
+			"type":     "TextBlock",
+			"text":     truncate(message.Summary, maxSummaryLength),
+			"fontType": "Monospace",
+			"wrap":     true,
+		})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.DashboardURL != "" {
+		card["actions"] = []interface{}{map[string]string{
+			"type":  "Action.OpenUrl",
+			"title": "Open in ProtoDiff",
+			"url":   message.DashboardURL,
+		}}
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{map[string]interface{}{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+}
+
+// alertmanagerAlert is an alert in the Alertmanager v2 API
+type alertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// alertmanagerAlerts renders the Alertmanager alerts a message fires or
+// resolves. Alerts are labelled per service rather than per pod, since a
+// service's pods are deduplicated into one alert. A drift fires
+// ProtoDiffSchemaDrift and a validation failure fires
+// ProtoDiffValidationFailed. Each is resolved only once no pod of the service
+// is drifted or failing respectively, whatever pod the message is about;
+// firing alerts end after lifetime unless re-sent.
+func alertmanagerAlerts(message Message, lifetime time.Duration) []alertmanagerAlert {
+	build := func(name string, endsAt time.Time) alertmanagerAlert {
+		labels := map[string]string{
+			"alertname": name,
+			"severity":  "warning",
+			"cluster":   message.Cluster,
+			"namespace": message.Namespace,
+			"service":   message.Service,
+		}
+		if message.Owner != "" {
+			labels["owner"] = message.Owner
+		}
+		annotations := map[string]string{
+			"summary":     message.Title,
+			"description": message.Summary,
+			"pod":         message.Pod,
+		}
+		if message.Module != "" {
+			annotations["module"] = message.Module
+		}
+		if message.DashboardURL != "" {
+			annotations["dashboard_url"] = message.DashboardURL
+		}
+		return alertmanagerAlert{
+			Labels:       labels,
+			Annotations:  annotations,
+			StartsAt:     message.Timestamp,
+			EndsAt:       endsAt,
+			GeneratorURL: message.DashboardURL,
+		}
+	}
+
+	// An alert firing for another pod of the service is left as it is
+	now := time.Now()
+	var alerts []alertmanagerAlert
+	switch {
+	case message.Kind == domain.AlertDrift:
+		alerts = append(alerts, build(alertNameDrift, now.Add(lifetime)))
+	case message.MismatchCount == 0:
+		alerts = append(alerts, build(alertNameDrift, now))
+	}
+	switch {
+	case message.Kind == domain.AlertFailed:
+		alerts = append(alerts, build(alertNameFailed, now.Add(lifetime)))
+	case message.UnknownCount == 0:
+		alerts = append(alerts, build(alertNameFailed, now))
+	}
+	return alerts
+}
+
+// shortCommit abbreviates a BSR commit ID
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// truncate shortens text to at most max bytes, ending with "..." when cut
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	cut := max - len("...")
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+func TestSlackPayload(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{
+		DashboardURL: "https://protodiff.example.com",
+		Webhooks:     []WebhookConfig{{Name: "slack", URL: server.URL, Format: FormatSlack}},
+	}, s)
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+
+	bodies := r.take()
+	if len(bodies) != 1 {
+		t.Fatalf("got %d posts, want 1", len(bodies))
+	}
+	var payload struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type     string `json:"type"`
+			Text     struct{ Text string }
+			Fields   []struct{ Text string }
+			Elements []struct{ URL string }
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(bodies[0], &payload); err != nil {
+		t.Fatalf("failed to decode Slack payload %s: %v", bodies[0], err)
+	}
+	if payload.Text == "" {
+		t.Error("Slack payload has no fallback text")
+	}
+	if len(payload.Blocks) < 3 {
+		t.Fatalf("got %d blocks, want header, fields and actions", len(payload.Blocks))
+	}
+	if header := payload.Blocks[0]; header.Type != "header" || !strings.HasPrefix(header.Text.Text, "🔴") {
+		t.Errorf("header block = %+v, want a header marked 🔴", header)
+	}
+	var facts []string
+	for _, field := range payload.Blocks[1].Fields {
+		facts = append(facts, field.Text)
+	}
+	for _, want := range []string{"*Service*\nusers", "*Pod*\nusers-a", "*Status*\nSYNC → MISMATCH"} {
+		if !strings.Contains(strings.Join(facts, "\n"), want) {
+			t.Errorf("fields %q do not contain %q", facts, want)
+		}
+	}
+	actions := payload.Blocks[len(payload.Blocks)-1]
+	if actions.Type != "actions" || len(actions.Elements) != 1 ||
+		!strings.HasPrefix(actions.Elements[0].URL, "https://protodiff.example.com/pod?") {
+		t.Errorf("actions block = %+v, want a dashboard button", actions)
+	}
+}
+
+func TestTeamsPayload(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "teams", URL: server.URL, Format: FormatTeams}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-a", domain.StatusUnknown)
+
+	bodies := r.take()
+	if len(bodies) != 1 {
+		t.Fatalf("got %d posts, want 1", len(bodies))
+	}
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Type  string `json:"type"`
+					Color string `json:"color"`
+					Facts []struct {
+						Title string `json:"title"`
+						Value string `json:"value"`
+					} `json:"facts"`
+				} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(bodies[0], &payload); err != nil {
+		t.Fatalf("failed to decode Teams payload %s: %v", bodies[0], err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 {
+		t.Fatalf("got payload %+v, want a message with one attachment", payload)
+	}
+	attachment := payload.Attachments[0]
+	if attachment.ContentType != "application/vnd.microsoft.card.adaptive" || attachment.Content.Type != "AdaptiveCard" {
+		t.Fatalf("attachment is %s %s, want an Adaptive Card", attachment.ContentType, attachment.Content.Type)
+	}
+	body := attachment.Content.Body
+	if len(body) < 2 || body[0].Color != "Warning" || body[1].Type != "FactSet" {
+		t.Fatalf("card body = %+v, want a Warning title and a fact set", body)
+	}
+	facts := make(map[string]string)
+	for _, fact := range body[1].Facts {
+		facts[fact.Title] = fact.Value
+	}
+	if facts["Service"] != "users" || facts["Namespace"] != "prod" || facts["Status"] != "SYNC → UNKNOWN" {
+		t.Errorf("facts = %v, want users in prod going SYNC → UNKNOWN", facts)
+	}
+}
+
+// alertmanagerStates decodes an Alertmanager payload into the state of each
+// alert name, firing or resolved
+func alertmanagerStates(t *testing.T, body []byte) map[string]string {
+	t.Helper()
+	var alerts []alertmanagerAlert
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		t.Fatalf("failed to decode Alertmanager payload %s: %v", body, err)
+	}
+	states := make(map[string]string)
+	for _, alert := range alerts {
+		if alert.Labels["service"] != "users" || alert.Labels["namespace"] != "prod" {
+			t.Errorf("alert labels = %v, want the users service in prod", alert.Labels)
+		}
+		if alert.EndsAt.After(time.Now()) {
+			states[alert.Labels["alertname"]] = "firing"
+		} else {
+			states[alert.Labels["alertname"]] = "resolved"
+		}
+	}
+	return states
+}
+
+func TestAlertmanagerResolvesOnlyWhenNoPodFails(t *testing.T) {
+	r, server := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "am", URL: server.URL, Format: FormatAlertmanager}}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-b", domain.StatusSync)
+
+	steps := []struct {
+		pod    string
+		status domain.DiffStatus
+		want   []map[string]string
+	}{
+		{"users-a", domain.StatusMismatch, []map[string]string{
+			{alertNameDrift: "firing", alertNameFailed: "resolved"},
+		}},
+		// users-a still drifts, so the failure leaves the drift alert firing
+		{"users-b", domain.StatusUnknown, []map[string]string{
+			{alertNameFailed: "firing"},
+		}},
+		// users-b still fails, so the service has not recovered
+		{"users-a", domain.StatusSync, nil},
+		// the failure is already reported for the service
+		{"users-a", domain.StatusUnknown, nil},
+		// the drift is within its cool-down, and still firing
+		{"users-b", domain.StatusMismatch, nil},
+	}
+	for i, step := range steps {
+		transition(t, a, s, step.pod, step.status)
+		bodies := r.take()
+		if len(bodies) != len(step.want) {
+			t.Fatalf("step %d: got %d posts, want %d", i, len(bodies), len(step.want))
+		}
+		for j, body := range bodies {
+			if got := alertmanagerStates(t, body); !equalStates(got, step.want[j]) {
+				t.Errorf("step %d: got alerts %v, want %v", i, got, step.want[j])
+			}
+		}
+	}
+
+	if err := a.webhooks[0].resendFiring(context.Background()); err != nil {
+		t.Fatalf("resendFiring() error = %v", err)
+	}
+	bodies := r.take()
+	if len(bodies) != 1 {
+		t.Fatalf("got %d re-sends, want 1", len(bodies))
+	}
+	want := map[string]string{alertNameDrift: "firing", alertNameFailed: "firing"}
+	if got := alertmanagerStates(t, bodies[0]); !equalStates(got, want) {
+		t.Errorf("re-sent alerts %v, want %v", got, want)
+	}
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-b", domain.StatusSync)
+	bodies = r.take()
+	want = map[string]string{alertNameDrift: "resolved", alertNameFailed: "resolved"}
+	if len(bodies) != 1 {
+		t.Fatalf("got %d posts once all pods recovered, want 1", len(bodies))
+	}
+	if got := alertmanagerStates(t, bodies[0]); !equalStates(got, want) {
+		t.Errorf("got alerts %v once all pods recovered, want %v", got, want)
+	}
+	if firing := a.webhooks[0].firing; len(firing) != 0 {
+		t.Errorf("still re-sending %d alerts after recovery, want none", len(firing))
+	}
+}
+
+func TestAlertmanagerResolvesWhenDriftedPodsDisappear(t *testing.T) {
+	tests := []struct {
+		name string
+		// replacements are the pods started in sync by the rollout
+		replacements []string
+	}{
+		{"rollout", []string{"users-c", "users-d"}},
+		{"service deleted", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, server := newReceiver(t)
+			s := store.NewMemory(store.Options{})
+			a := NewAlerter(Config{Webhooks: []WebhookConfig{{Name: "am", URL: server.URL, Format: FormatAlertmanager}}}, s)
+
+			transition(t, a, s, "users-a", domain.StatusMismatch)
+			transition(t, a, s, "users-b", domain.StatusMismatch)
+			r.take()
+			for _, pod := range tt.replacements {
+				transition(t, a, s, pod, domain.StatusSync)
+			}
+			s.Delete(podResult("users-a", domain.StatusMismatch).Key())
+			s.Delete(podResult("users-b", domain.StatusMismatch).Key())
+
+			// As done by Run at the end of a scan cycle
+			a.recoverServices(context.Background())
+			a.resolveFiring(context.Background())
+
+			states := make(map[string]string)
+			for _, body := range r.take() {
+				for name, state := range alertmanagerStates(t, body) {
+					states[name] = state
+				}
+			}
+			if states[alertNameDrift] != "resolved" {
+				t.Errorf("got alerts %v, want %s resolved", states, alertNameDrift)
+			}
+			if firing := a.webhooks[0].firing; len(firing) != 0 {
+				t.Errorf("still re-sending %d alerts, want none", len(firing))
+			}
+		})
+	}
+}
+
+func TestAlertmanagerAlerts(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+		want    map[string]string
+	}{
+		{
+			name:    "drift of the only pod",
+			message: Message{Kind: domain.AlertDrift, PodCount: 1, MismatchCount: 1},
+			want:    map[string]string{alertNameDrift: "firing", alertNameFailed: "resolved"},
+		},
+		{
+			name:    "drift while another pod fails",
+			message: Message{Kind: domain.AlertDrift, PodCount: 2, MismatchCount: 1, UnknownCount: 1},
+			want:    map[string]string{alertNameDrift: "firing"},
+		},
+		{
+			name:    "failure of the last drifted pod",
+			message: Message{Kind: domain.AlertFailed, PodCount: 2, UnknownCount: 1},
+			want:    map[string]string{alertNameDrift: "resolved", alertNameFailed: "firing"},
+		},
+		{
+			name:    "recovery while another pod drifts",
+			message: Message{Kind: domain.AlertRecovered, PodCount: 2, MismatchCount: 1},
+			want:    map[string]string{alertNameFailed: "resolved"},
+		},
+		{
+			name:    "recovery of every pod",
+			message: Message{Kind: domain.AlertRecovered, PodCount: 2},
+			want:    map[string]string{alertNameDrift: "resolved", alertNameFailed: "resolved"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]string)
+			for _, alert := range alertmanagerAlerts(tt.message, time.Minute) {
+				if alert.EndsAt.After(time.Now()) {
+					got[alert.Labels["alertname"]] = "firing"
+				} else {
+					got[alert.Labels["alertname"]] = "resolved"
+				}
+			}
+			if !equalStates(got, tt.want) {
+				t.Errorf("alertmanagerAlerts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// equalStates compares alert states by name
+func equalStates(got, want map[string]string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for name, state := range want {
+		if got[name] != state {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Message is the alert as rendered into payloads. It is the data passed to
+// webhook templates and the default webhook payload.
+type Message struct {
+	Kind domain.AlertKind `json:"kind"`
+	// Title is a one-line description, e.g. "Schema drift: user-service in prod"
+	Title string `json:"title"`
+	// Summary describes the diff or the validation error
+	Summary   string            `json:"summary"`
+	From      domain.DiffStatus `json:"from"`
+	Status    domain.DiffStatus `json:"status"`
+	Cluster   string            `json:"cluster"`
+	Namespace string            `json:"namespace"`
+	Pod       string            `json:"pod"`
+	Service   string            `json:"service"`
+	// Owner is the team or person owning the service ("" when not annotated)
+	Owner string `json:"owner,omitempty"`
+	// Workload is the owning controller, e.g. "Deployment/user-service" ("" for bare pods)
+	Workload string             `json:"workload,omitempty"`
+	Module   string             `json:"module,omitempty"`
+	Commit   string             `json:"commit,omitempty"`
+	Image    string             `json:"image,omitempty"`
+	Diff     *domain.SchemaDiff `json:"diff,omitempty"`
+	// PodCount is the number of pods of the service; MismatchCount and
+	// UnknownCount count those currently drifted or not validated
+	PodCount      int `json:"pod_count"`
+	MismatchCount int `json:"mismatch_count"`
+	UnknownCount  int `json:"unknown_count"`
+	// DashboardURL links to the pod's page ("" without a configured dashboard URL)
+	DashboardURL string    `json:"dashboard_url,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// templateFuncs are available in payload templates
+var templateFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{json .Title}} for a quoted and escaped string
+	"json": func(v interface{}) (string, error) {
+		data, err := marshalJSON(v)
+		return string(data), err
+	},
+	"join": strings.Join,
+}
+
+// marshalJSON encodes a value as JSON, leaving characters such as & in links unescaped
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// newMessage renders an alert about one of the given pods of a service,
+// linking to the dashboard at dashboardURL
+func newMessage(alert domain.Alert, service []*domain.ScanResult, dashboardURL string) Message {
+	result := alert.Result
+	message := Message{
+		Kind:      alert.Kind,
+		Title:     alertTitle(alert),
+		Summary:   result.Message,
+		From:      alert.From,
+		Status:    result.Status,
+		Cluster:   result.ClusterName,
+		Namespace: result.PodNamespace,
+		Pod:       result.PodName,
+		Service:   result.ServiceName,
+		Owner:     result.Owner,
+		Module:    result.BSRModule,
+		Commit:    result.BSRCommit,
+		Image:     result.Image,
+		Diff:      result.SchemaDiff,
+		Timestamp: alert.Timestamp,
+	}
+	if result.Workload != nil {
+		message.Workload = result.Workload.Kind + "/" + result.Workload.Name
+	}
+	message.PodCount = len(service)
+	for _, pod := range service {
+		switch pod.Status {
+		case domain.StatusMismatch:
+			message.MismatchCount++
+		case domain.StatusUnknown:
+			message.UnknownCount++
+		}
+	}
+	if dashboardURL != "" {
+		query := url.Values{}
+		query.Set("cluster", result.ClusterName)
+		query.Set("namespace", result.PodNamespace)
+		query.Set("pod", result.PodName)
+		if result.KubeService != "" {
+			query.Set("kube_service", result.KubeService)
+		}
+		message.DashboardURL = strings.TrimSuffix(dashboardURL, "/") + "/pod?" + query.Encode()
+	}
+	return message
+}
+
+// alertTitle describes an alert in one line
+func alertTitle(alert domain.Alert) string {
+	var what string
+	switch alert.Kind {
+	case domain.AlertDrift:
+		what = "Schema drift"
+	case domain.AlertRecovered:
+		what = "Schema back in sync"
+	default:
+		what = "Schema validation failing"
+	}
+	return fmt.Sprintf("%s: %s in %s", what, alert.Result.ServiceName, alert.Result.PodNamespace)
+}
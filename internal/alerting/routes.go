@@ -0,0 +1,91 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+// route sends matching alerts to its webhooks
+type route struct {
+	namespaces map[string]bool
+	owners     map[string]bool
+	services   map[string]bool
+	clusters   map[string]bool
+	webhooks   []*webhook
+	next       bool
+}
+
+// newRoute compiles a validated route, resolving webhook names
+func newRoute(config RouteConfig, webhooks map[string]*webhook) route {
+	r := route{
+		namespaces: stringSet(config.Namespaces),
+		owners:     stringSet(config.Owners),
+		services:   stringSet(config.Services),
+		clusters:   stringSet(config.Clusters),
+		next:       config.Continue,
+	}
+	for _, name := range config.Webhooks {
+		r.webhooks = append(r.webhooks, webhooks[name])
+	}
+	return r
+}
+
+// matches reports whether the route applies to a message
+func (r route) matches(message Message) bool {
+	return matchesSet(r.namespaces, message.Namespace) &&
+		matchesSet(r.owners, message.Owner) &&
+		matchesSet(r.services, message.Service) &&
+		matchesSet(r.clusters, message.Cluster)
+}
+
+// routeMessage returns the webhooks a message is routed to, each at most once.
+// Without routes, every webhook receives every message.
+func routeMessage(routes []route, webhooks []*webhook, message Message) []*webhook {
+	if len(routes) == 0 {
+		return webhooks
+	}
+
+	var targets []*webhook
+	seen := make(map[*webhook]bool)
+	for _, r := range routes {
+		if !r.matches(message) {
+			continue
+		}
+		for _, w := range r.webhooks {
+			if !seen[w] {
+				seen[w] = true
+				targets = append(targets, w)
+			}
+		}
+		if !r.next {
+			break
+		}
+	}
+	return targets
+}
+
+// stringSet builds a set from a list (nil for an empty list)
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// matchesSet reports whether value is in set; a nil set matches everything
+func matchesSet(set map[string]bool, value string) bool {
+	return set == nil || set[value]
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+func TestAlerterRouting(t *testing.T) {
+	payments, paymentsServer := newReceiver(t)
+	platform, platformServer := newReceiver(t)
+	catchAll, catchAllServer := newReceiver(t)
+	recoveries, recoveriesServer := newReceiver(t)
+
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{
+		Webhooks: []WebhookConfig{
+			{Name: "payments", URL: paymentsServer.URL},
+			{Name: "platform", URL: platformServer.URL},
+			{Name: "catch-all", URL: catchAllServer.URL},
+			{Name: "recoveries", URL: recoveriesServer.URL, Kinds: []domain.AlertKind{domain.AlertRecovered}},
+		},
+		Routes: []RouteConfig{
+			{Namespaces: []string{"payments"}, Webhooks: []string{"payments", "recoveries"}, Continue: true},
+			{Owners: []string{"team-platform"}, Webhooks: []string{"platform"}},
+			{Webhooks: []string{"catch-all", "payments"}},
+		},
+	}, s)
+
+	tests := []struct {
+		name      string
+		namespace string
+		owner     string
+		kind      domain.AlertKind
+		want      map[*receiver]int
+	}{
+		{
+			name:      "continues past the namespace route",
+			namespace: "payments",
+			kind:      domain.AlertDrift,
+			want:      map[*receiver]int{payments: 1, catchAll: 1},
+		},
+		{
+			name:      "stops at the owner route",
+			namespace: "payments",
+			owner:     "team-platform",
+			kind:      domain.AlertDrift,
+			want:      map[*receiver]int{payments: 1, platform: 1},
+		},
+		{
+			name:      "falls through to the catch-all",
+			namespace: "orders",
+			kind:      domain.AlertDrift,
+			want:      map[*receiver]int{payments: 1, catchAll: 1},
+		},
+		{
+			name:      "filters webhooks by kind",
+			namespace: "payments",
+			owner:     "team-platform",
+			kind:      domain.AlertRecovered,
+			want:      map[*receiver]int{payments: 1, platform: 1, recoveries: 1},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := domain.StatusMismatch
+			if tt.kind == domain.AlertRecovered {
+				status = domain.StatusSync
+			}
+			result := &domain.ScanResult{
+				ClusterName:  "default",
+				PodNamespace: tt.namespace,
+				PodName:      "api-0",
+				ServiceName:  fmt.Sprintf("api-%d", i),
+				Owner:        tt.owner,
+				Status:       status,
+				LastChecked:  time.Now(),
+			}
+			s.Set(result)
+			a.process(context.Background(), domain.Alert{Kind: tt.kind, From: domain.StatusUnknown, Result: result, Timestamp: time.Now()})
+
+			for _, r := range []*receiver{payments, platform, catchAll, recoveries} {
+				if got := len(r.take()); got != tt.want[r] {
+					t.Errorf("receiver got %d alerts, want %d", got, tt.want[r])
+				}
+			}
+		})
+	}
+}
+
+func TestAlerterWithoutRoutesSendsEverywhere(t *testing.T) {
+	first, firstServer := newReceiver(t)
+	second, secondServer := newReceiver(t)
+	s := store.NewMemory(store.Options{})
+	a := NewAlerter(Config{Webhooks: []WebhookConfig{
+		{Name: "first", URL: firstServer.URL},
+		{Name: "second", URL: secondServer.URL},
+	}}, s)
+
+	transition(t, a, s, "users-a", domain.StatusSync)
+	transition(t, a, s, "users-a", domain.StatusMismatch)
+
+	for name, r := range map[string]*receiver{"first": first, "second": second} {
+		if got := len(r.take()); got != 1 {
+			t.Errorf("webhook %s got %d alerts, want 1", name, got)
+		}
+	}
+}
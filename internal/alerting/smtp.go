@@ -0,0 +1,154 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// smtpTimeout bounds the delivery of a single email
+const smtpTimeout = 30 * time.Second
+
+// sendMail delivers a message to recipients through the SMTP server. The
+// connection is upgraded with STARTTLS when the server offers it, and
+// credentials are only sent over TLS (or to localhost).
+func (c SMTPConfig) sendMail(ctx context.Context, to []string, message []byte) error {
+	from, err := mail.ParseAddress(c.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	password, err := c.password()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(c.port()))
+	tlsConfig := &tls.Config{ServerName: c.Host}
+	var conn net.Conn
+	if c.TLS {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet SMTP server %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if !c.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if c.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.Username, password, c.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	for _, recipient := range to {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+		if err := client.Rcpt(address.Address); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", address.Address, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// password returns the configured password, reading it from its file if set
+func (c SMTPConfig) password() (string, error) {
+	if c.PasswordFile == "" {
+		return c.Password, nil
+	}
+	data, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read SMTP password: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// port returns the configured port
+func (c SMTPConfig) port() int {
+	if c.Port == 0 {
+		return defaultSMTPPort
+	}
+	return c.Port
+}
+
+// composeMail builds an HTML email with its headers
+func composeMail(from string, to []string, subject string, html []byte, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from)
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/html; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write(html); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+const (
+	// retryBackoff is the delay before the first retry, doubled for each further retry
+	retryBackoff = time.Second
+	// alertmanagerResendInterval is how often firing alerts are re-sent to Alertmanager
+	alertmanagerResendInterval = time.Minute
+	// alertmanagerLifetime is how long Alertmanager keeps an alert firing without
+	// hearing from ProtoDiff again, so alerts resolve if ProtoDiff goes away
+	alertmanagerLifetime = 5 * alertmanagerResendInterval
+)
+
+// webhook delivers alerts as JSON to an HTTP endpoint
+type webhook struct {
+	name     string
+	url      string
+	headers  map[string]string
+	kinds    map[domain.AlertKind]bool
+	format   string
+	template *template.Template
+	retries  int
+	client   *http.Client
+
+	// firing holds the alerts firing in Alertmanager by alert name and service,
+	// re-sent until resolved (alertmanager format only)
+	firing map[string]alertmanagerAlert
+}
+
+// newWebhook creates a webhook from its validated configuration
+func newWebhook(config WebhookConfig) *webhook {
+	w := &webhook{
+		name:    config.Name,
+		url:     config.URL,
+		headers: config.Headers,
+		format:  config.Format,
+		retries: defaultRetries,
+		client:  &http.Client{Timeout: defaultWebhookTimeout},
+	}
+	if w.name == "" {
+		if u, err := url.Parse(config.URL); err == nil {
+			w.name = u.Host
+		}
+	}
+	if len(config.Kinds) > 0 {
+		w.kinds = make(map[domain.AlertKind]bool, len(config.Kinds))
+		for _, kind := range config.Kinds {
+			w.kinds[kind] = true
+		}
+	}
+	if w.format == "" {
+		w.format = FormatJSON
+	}
+	if config.Template != "" {
+		w.template, _ = parseTemplate(config.Template)
+	}
+	if w.format == FormatAlertmanager {
+		w.firing = make(map[string]alertmanagerAlert)
+	}
+	if config.Retries != nil {
+		w.retries = *config.Retries
+	}
+	if config.Timeout != nil && config.Timeout.Duration > 0 {
+		w.client.Timeout = config.Timeout.Duration
+	}
+	return w
+}
+
+// accepts reports whether the webhook wants alerts of a kind
+func (w *webhook) accepts(kind domain.AlertKind) bool {
+	return w.kinds == nil || w.kinds[kind]
+}
+
+// payload renders the JSON body for a message in the webhook's format
+func (w *webhook) payload(message Message) ([]byte, error) {
+	switch w.format {
+	case FormatSlack:
+		return marshalJSON(slackPayload(message))
+	case FormatTeams:
+		return marshalJSON(teamsPayload(message))
+	case FormatAlertmanager:
+		alerts := alertmanagerAlerts(message, alertmanagerLifetime)
+		w.trackFiring(alerts)
+		return marshalJSON(alerts)
+	}
+
+	if w.template == nil {
+		return marshalJSON(message)
+	}
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, message); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("template did not render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// send delivers a message, retrying with exponential backoff on network
+// errors, 429 and 5xx responses
+func (w *webhook) send(ctx context.Context, message Message) error {
+	body, err := w.payload(message)
+	if err != nil {
+		return err
+	}
+
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt == w.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// trackFiring remembers firing alerts for re-sending and forgets resolved ones
+func (w *webhook) trackFiring(alerts []alertmanagerAlert) {
+	now := time.Now()
+	for _, alert := range alerts {
+		key := alert.Labels["alertname"] + "/" + alert.Labels["cluster"] + "/" + alert.Labels["namespace"] + "/" + alert.Labels["service"]
+		if alert.EndsAt.After(now) {
+			w.firing[key] = alert
+		} else {
+			delete(w.firing, key)
+		}
+	}
+}
+
+// resendFiring re-sends the firing alerts to Alertmanager, extending their lifetime
+func (w *webhook) resendFiring(ctx context.Context) error {
+	if len(w.firing) == 0 {
+		return nil
+	}
+	endsAt := time.Now().Add(alertmanagerLifetime)
+	alerts := make([]alertmanagerAlert, 0, len(w.firing))
+	for key, alert := range w.firing {
+		alert.EndsAt = endsAt
+		w.firing[key] = alert
+		alerts = append(alerts, alert)
+	}
+
+	body, err := marshalJSON(alerts)
+	if err != nil {
+		return err
+	}
+	_, err = w.post(ctx, body)
+	return err
+}
+
+// resolveFiring resolves the firing alerts for which stillFiring returns false.
+// They are forgotten even if the delivery fails, and then expire in
+// Alertmanager after their lifetime.
+func (w *webhook) resolveFiring(ctx context.Context, stillFiring func(alert alertmanagerAlert) bool) error {
+	now := time.Now()
+	var resolved []alertmanagerAlert
+	for key, alert := range w.firing {
+		if stillFiring(alert) {
+			continue
+		}
+		alert.EndsAt = now
+		resolved = append(resolved, alert)
+		delete(w.firing, key)
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	body, err := marshalJSON(resolved)
+	if err != nil {
+		return err
+	}
+	_, err = w.post(ctx, body)
+	return err
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (w *webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return false, nil
+}
@@ -23,11 +23,37 @@
 //   - DEFAULT_BSR_TEMPLATE: Template for BSR module paths like "buf.build/org/{service}"
 //   - WEB_ADDR: Web server address (default: ":18080")
 //   - SCAN_INTERVAL: Duration between scans (default: "30m")
+//   - KUBECONFIG: Kubeconfig files for out-of-cluster mode, colon-separated (default: in-cluster config)
+//   - KUBE_CONTEXT: Kubeconfig context to use (default: current context)
+//   - PORT_FORWARD: Reach pods through API server port-forwarding (default: "false")
+//   - CLUSTER_NAME: Name of the cluster protodiff runs against (default: "default")
+//   - CLUSTERS: Additional clusters to scan, e.g. "eu=context:prod-eu,us=secret:protodiff-system/prod-us"
+//   - SERVICE_NAME_LABEL: Pod label holding the service name (default: "app")
+//   - DISCOVERY_SELECTOR: Opt-in label selector for gRPC pods (default: "grpc-service=true")
+//   - INCLUDE_NAMESPACES: Comma-separated namespaces to scan (default: all)
+//   - EXCLUDE_NAMESPACES: Comma-separated namespaces to skip
+//   - DISCOVERY_MODE: "pods" to scan pods directly, "services" to go through Services and EndpointSlices (default: "pods")
+//   - RECORD_EVENTS: Record Kubernetes Events on pods and workloads when their status changes (default: "true")
+//   - ALERTS_CONFIG: Path of the alerting configuration file with webhooks and the email digest (default: alerting disabled)
+//   - LEADER_ELECTION: Only scan on the replica holding the leader Lease (default: "false")
+//   - LEASE_NAME: Name of the leader election Lease (default: "protodiff")
+//   - LEASE_NAMESPACE: Namespace of the leader election Lease (default: CONFIGMAP_NAMESPACE)
+//   - POD_NAME: Replica identity used for leader election (default: hostname)
+//   - ADVERTISE_ADDR: Address other replicas use to fetch this replica's results (default: POD_IP with the WEB_ADDR port)
+//   - SHARDING: Split scanning across all replicas by consistent hashing (default: "false")
+//   - SHARD_BY: "pod" to assign pods individually, "service" to keep a service's pods together (default: "pod")
+//   - STORE_BACKEND: "memory" to keep results in memory, "bolt" to persist them to a BoltDB file (default: "memory")
+//   - STORE_PATH: Path of the BoltDB file for the bolt backend (default: "/data/protodiff.db")
+//   - HISTORY_RETENTION: How long status transitions are kept (default: "336h")
+//   - HISTORY_LIMIT: Maximum status transitions kept per service (default: "1000")
 package config
 
 import (
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -37,6 +63,26 @@ const (
 	defaultConfigMapName      = "protodiff-mapping"
 	defaultWebAddr            = ":18080"
 	defaultScanInterval       = 30 * time.Minute
+	defaultClusterName        = "default"
+	defaultLeaseName          = "protodiff"
+	defaultStorePath          = "/data/protodiff.db"
+	defaultHistoryRetention   = 14 * 24 * time.Hour
+	defaultHistoryLimit       = 1000
+
+	// DiscoveryModePods discovers pods directly by label
+	DiscoveryModePods = "pods"
+	// DiscoveryModeServices discovers backends through Services and EndpointSlices
+	DiscoveryModeServices = "services"
+
+	// ShardByPod assigns each pod to a replica
+	ShardByPod = "pod"
+	// ShardByService assigns all pods of a service to the same replica
+	ShardByService = "service"
+
+	// StoreBackendMemory keeps results in memory only
+	StoreBackendMemory = "memory"
+	// StoreBackendBolt persists results to a BoltDB file
+	StoreBackendBolt = "bolt"
 
 	// Environment variable names
 	envConfigMapNamespace = "CONFIGMAP_NAMESPACE"
@@ -44,6 +90,34 @@ const (
 	envBSRTemplate        = "DEFAULT_BSR_TEMPLATE"
 	envWebAddr            = "WEB_ADDR"
 	envScanInterval       = "SCAN_INTERVAL"
+	envKubeconfig         = "KUBECONFIG"
+	envKubeContext        = "KUBE_CONTEXT"
+	envPortForward        = "PORT_FORWARD"
+	envClusterName        = "CLUSTER_NAME"
+	envClusters           = "CLUSTERS"
+	envServiceNameLabel   = "SERVICE_NAME_LABEL"
+	envDiscoverySelector  = "DISCOVERY_SELECTOR"
+	envIncludeNamespaces  = "INCLUDE_NAMESPACES"
+	envExcludeNamespaces  = "EXCLUDE_NAMESPACES"
+	envDiscoveryMode      = "DISCOVERY_MODE"
+	envRecordEvents       = "RECORD_EVENTS"
+	envAlertsConfig       = "ALERTS_CONFIG"
+	envLeaderElection     = "LEADER_ELECTION"
+	envLeaseName          = "LEASE_NAME"
+	envLeaseNamespace     = "LEASE_NAMESPACE"
+	envPodName            = "POD_NAME"
+	envPodIP              = "POD_IP"
+	envAdvertiseAddr      = "ADVERTISE_ADDR"
+	envSharding           = "SHARDING"
+	envShardBy            = "SHARD_BY"
+	envStoreBackend       = "STORE_BACKEND"
+	envStorePath          = "STORE_PATH"
+	envHistoryRetention   = "HISTORY_RETENTION"
+	envHistoryLimit       = "HISTORY_LIMIT"
+
+	// Cluster source prefixes used in CLUSTERS entries
+	clusterSourceContext = "context:"
+	clusterSourceSecret  = "secret:"
 )
 
 // Config holds the application configuration
@@ -60,6 +134,58 @@ type Config struct {
 
 	// Web server settings
 	WebAddr string
+
+	// Kubernetes connection settings. Kubeconfig is an explicit kubeconfig
+	// path from the -kubeconfig flag; $KUBECONFIG is read by the client itself.
+	Kubeconfig  string
+	KubeContext string
+	PortForward bool
+
+	// Multi-cluster settings
+	ClusterName string
+	Clusters    []ClusterConfig
+
+	// Discovery settings
+	ServiceNameLabel  string
+	DiscoverySelector string
+	IncludeNamespaces []string
+	ExcludeNamespaces []string
+	DiscoveryMode     string
+
+	// Notification settings
+	RecordEvents bool
+	// AlertsConfig is the path of the alerting configuration ("" disables alerting)
+	AlertsConfig string
+
+	// High availability settings
+	LeaderElection bool
+	LeaseName      string
+	LeaseNamespace string
+	// PodName identifies this replica
+	PodName string
+	// AdvertiseAddr is the host:port other replicas reach this replica's web server on
+	AdvertiseAddr string
+	// Sharding splits scanning across replicas; ShardBy is ShardByPod or ShardByService
+	Sharding bool
+	ShardBy  string
+
+	// Storage settings
+	StoreBackend     string
+	StorePath        string
+	HistoryRetention time.Duration
+	HistoryLimit     int
+}
+
+// ClusterConfig describes an additional cluster to scan.
+// Exactly one of Context or SecretName is set.
+type ClusterConfig struct {
+	// Name identifies the cluster in results and on the dashboard
+	Name string
+	// Context is a kubeconfig context for the cluster
+	Context string
+	// SecretNamespace and SecretName locate a Secret holding the cluster's kubeconfig
+	SecretNamespace string
+	SecretName      string
 }
 
 // Load loads configuration from environment variables with defaults
@@ -70,6 +196,46 @@ func Load() Config {
 		BSRTemplate:        getEnv(envBSRTemplate, ""),
 		WebAddr:            getEnv(envWebAddr, defaultWebAddr),
 		ScanInterval:       defaultScanInterval,
+		KubeContext:        getEnv(envKubeContext, ""),
+		PortForward:        getEnvBool(envPortForward, false),
+		ClusterName:        getEnv(envClusterName, defaultClusterName),
+		Clusters:           parseClusters(os.Getenv(envClusters)),
+		ServiceNameLabel:   getEnv(envServiceNameLabel, ""),
+		DiscoverySelector:  getEnv(envDiscoverySelector, ""),
+		IncludeNamespaces:  getEnvList(envIncludeNamespaces),
+		ExcludeNamespaces:  getEnvList(envExcludeNamespaces),
+		DiscoveryMode:      getEnv(envDiscoveryMode, DiscoveryModePods),
+		RecordEvents:       getEnvBool(envRecordEvents, true),
+		AlertsConfig:       getEnv(envAlertsConfig, ""),
+		LeaderElection:     getEnvBool(envLeaderElection, false),
+		LeaseName:          getEnv(envLeaseName, defaultLeaseName),
+		PodName:            getEnv(envPodName, hostname()),
+		Sharding:           getEnvBool(envSharding, false),
+		ShardBy:            getEnv(envShardBy, ShardByPod),
+		StoreBackend:       getEnv(envStoreBackend, StoreBackendMemory),
+		StorePath:          getEnv(envStorePath, defaultStorePath),
+		HistoryRetention:   getEnvDuration(envHistoryRetention, defaultHistoryRetention),
+		HistoryLimit:       getEnvInt(envHistoryLimit, defaultHistoryLimit),
+	}
+	config.LeaseNamespace = getEnv(envLeaseNamespace, config.ConfigMapNamespace)
+	config.AdvertiseAddr = getEnv(envAdvertiseAddr, defaultAdvertiseAddr(os.Getenv(envPodIP), config.WebAddr))
+
+	if config.DiscoveryMode != DiscoveryModePods && config.DiscoveryMode != DiscoveryModeServices {
+		log.Printf("Warning: Invalid DISCOVERY_MODE '%s', using default %s", config.DiscoveryMode, DiscoveryModePods)
+		config.DiscoveryMode = DiscoveryModePods
+	}
+
+	if config.ShardBy != ShardByPod && config.ShardBy != ShardByService {
+		log.Printf("Warning: Invalid SHARD_BY '%s', using default %s", config.ShardBy, ShardByPod)
+		config.ShardBy = ShardByPod
+	}
+	if config.StoreBackend != StoreBackendMemory && config.StoreBackend != StoreBackendBolt {
+		log.Printf("Warning: Invalid STORE_BACKEND '%s', using default %s", config.StoreBackend, StoreBackendMemory)
+		config.StoreBackend = StoreBackendMemory
+	}
+	if config.Sharding && config.LeaderElection {
+		log.Printf("Warning: SHARDING replaces LEADER_ELECTION, every replica scans its own share")
+		config.LeaderElection = false
 	}
 
 	// Parse scan interval if provided
@@ -86,6 +252,37 @@ func Load() Config {
 	log.Printf("  BSR Template: %s", config.BSRTemplate)
 	log.Printf("  Web Address: %s", config.WebAddr)
 	log.Printf("  Scan Interval: %s", config.ScanInterval)
+	if kubeconfig := os.Getenv(envKubeconfig); kubeconfig != "" || config.KubeContext != "" {
+		log.Printf("  Kubeconfig: %s (context: %s)", kubeconfig, config.KubeContext)
+	}
+	log.Printf("  Port Forward: %t", config.PortForward)
+	log.Printf("  Cluster Name: %s", config.ClusterName)
+	for _, cluster := range config.Clusters {
+		log.Printf("  Additional Cluster: %s", cluster.Name)
+	}
+	log.Printf("  Discovery Mode: %s", config.DiscoveryMode)
+	log.Printf("  Record Events: %t", config.RecordEvents)
+	if config.StoreBackend == StoreBackendBolt {
+		log.Printf("  Store: %s (%s)", config.StoreBackend, config.StorePath)
+	} else {
+		log.Printf("  Store: %s", config.StoreBackend)
+	}
+	log.Printf("  History: %s, up to %d transitions per service", config.HistoryRetention, config.HistoryLimit)
+	if config.LeaderElection {
+		log.Printf("  Leader Election: %s/%s (identity: %s, advertise: %s)", config.LeaseNamespace, config.LeaseName, config.PodName, config.AdvertiseAddr)
+	}
+	if config.Sharding {
+		log.Printf("  Sharding: by %s in group %s/%s (identity: %s, advertise: %s)", config.ShardBy, config.LeaseNamespace, config.LeaseName, config.PodName, config.AdvertiseAddr)
+	}
+	if config.DiscoverySelector != "" {
+		log.Printf("  Discovery Selector: %s", config.DiscoverySelector)
+	}
+	if len(config.IncludeNamespaces) > 0 {
+		log.Printf("  Include Namespaces: %s", strings.Join(config.IncludeNamespaces, ","))
+	}
+	if len(config.ExcludeNamespaces) > 0 {
+		log.Printf("  Exclude Namespaces: %s", strings.Join(config.ExcludeNamespaces, ","))
+	}
 
 	return config
 }
@@ -97,3 +294,115 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList retrieves a comma-separated environment variable as a list,
+// trimming whitespace and dropping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvDuration retrieves a duration environment variable or returns a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Warning: Invalid %s '%s', using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvInt retrieves a positive integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Warning: Invalid %s '%s', using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// hostname returns the host name, which is the pod name inside Kubernetes
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// defaultAdvertiseAddr combines the pod IP with the port of the web server address
+func defaultAdvertiseAddr(podIP, webAddr string) string {
+	if podIP == "" {
+		return ""
+	}
+	_, port, err := net.SplitHostPort(webAddr)
+	if err != nil {
+		return ""
+	}
+	return net.JoinHostPort(podIP, port)
+}
+
+// getEnvBool retrieves a boolean environment variable or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid %s '%s', using default %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// parseClusters parses a comma-separated list of "name=source" cluster entries.
+// The source is either "context:<kubeconfig-context>" or "secret:<namespace>/<name>";
+// a source without a prefix is treated as a kubeconfig context.
+func parseClusters(value string) []ClusterConfig {
+	var clusters []ClusterConfig
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, source, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			log.Printf("Warning: Invalid %s entry '%s', expected name=source", envClusters, entry)
+			continue
+		}
+
+		cluster := ClusterConfig{Name: strings.TrimSpace(name)}
+		source = strings.TrimSpace(source)
+		switch {
+		case strings.HasPrefix(source, clusterSourceSecret):
+			namespace, secretName, ok := strings.Cut(strings.TrimPrefix(source, clusterSourceSecret), "/")
+			if !ok || namespace == "" || secretName == "" {
+				log.Printf("Warning: Invalid secret reference in %s entry '%s', expected secret:namespace/name", envClusters, entry)
+				continue
+			}
+			cluster.SecretNamespace = namespace
+			cluster.SecretName = secretName
+		default:
+			cluster.Context = strings.TrimPrefix(source, clusterSourceContext)
+		}
+
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+// AlertKind tells what a status transition means for a pod
+type AlertKind string
+
+const (
+	// AlertDrift means a pod's schema drifted from BSR
+	AlertDrift AlertKind = "drift"
+	// AlertRecovered means a pod is back in sync
+	AlertRecovered AlertKind = "recovered"
+	// AlertFailed means a previously validated pod can no longer be validated
+	AlertFailed AlertKind = "failed"
+)
+
+// Alert reports a status transition of a pod
+type Alert struct {
+	Kind AlertKind `json:"kind"`
+	// From is the previous status (UNKNOWN for pods seen for the first time)
+	From   DiffStatus  `json:"from"`
+	Result *ScanResult `json:"result"`
+	// Timestamp is when the transition was observed
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewAlert returns the alert for the change from the previous to the current
+// result of a pod, or nil when the change is not worth an alert. Like the
+// Kubernetes Events, only transitions are reported: a pod first seen in sync
+// or one that was never validated is not news.
+func NewAlert(previous, current *ScanResult) *Alert {
+	alert := &Alert{
+		From:      StatusUnknown,
+		Result:    current,
+		Timestamp: current.LastChecked,
+	}
+	if previous != nil {
+		if previous.Status == current.Status {
+			return nil
+		}
+		alert.From = previous.Status
+	}
+
+	switch current.Status {
+	case StatusMismatch:
+		alert.Kind = AlertDrift
+	case StatusSync:
+		if previous == nil {
+			return nil
+		}
+		alert.Kind = AlertRecovered
+	default:
+		if previous == nil || previous.Status == StatusUnknown {
+			return nil
+		}
+		alert.Kind = AlertFailed
+	}
+	return alert
+}
+
+// DedupKey groups alerts describing the same transition of a service, so
+// replicas of a workload drifting the same way produce a single alert
+func (a Alert) DedupKey() string {
+	key := string(a.Kind) + "/" + a.Result.ClusterName + "/" + a.Result.PodNamespace + "/" + a.Result.ServiceName
+	if a.Kind == AlertDrift {
+		key += "/" + a.Result.LiveFingerprint + "@" + a.Result.BSRCommit
+	}
+	return key
+}
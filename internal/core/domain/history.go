@@ -0,0 +1,218 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// HistoryEvent records a change in the comparison outcome of a pod, with a
+// snapshot of the diff at that time
+type HistoryEvent struct {
+	ClusterName  string `json:"cluster_name"`
+	PodNamespace string `json:"pod_namespace"`
+	PodName      string `json:"pod_name"`
+	ServiceName  string `json:"service_name"`
+	// KubeService is the Service the pod was reached through (service discovery mode)
+	KubeService string `json:"kube_service,omitempty"`
+	// From is the previous status (empty for the first result of a pod)
+	From DiffStatus `json:"from,omitempty"`
+	// To is the new status (empty when the pod was removed)
+	To      DiffStatus `json:"to"`
+	Message string     `json:"message"`
+	// BSRModule, BSRCommit and LiveFingerprint identify what was compared
+	BSRModule       string `json:"bsr_module,omitempty"`
+	BSRCommit       string `json:"bsr_commit,omitempty"`
+	LiveFingerprint string `json:"live_fingerprint,omitempty"`
+	// SchemaDiff is the diff at the time of the change
+	SchemaDiff *SchemaDiff `json:"schema_diff,omitempty"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// NewHistoryEvent returns the event recording the change from the previous to
+// the current result of a pod, or nil when nothing worth recording changed.
+// Besides status transitions, a drifted pod whose diff changed is recorded.
+func NewHistoryEvent(previous, current *ScanResult) *HistoryEvent {
+	event := &HistoryEvent{
+		ClusterName:     current.ClusterName,
+		PodNamespace:    current.PodNamespace,
+		PodName:         current.PodName,
+		ServiceName:     current.ServiceName,
+		KubeService:     current.KubeService,
+		To:              current.Status,
+		Message:         current.Message,
+		BSRModule:       current.BSRModule,
+		BSRCommit:       current.BSRCommit,
+		LiveFingerprint: current.LiveFingerprint,
+		SchemaDiff:      current.SchemaDiff,
+		Timestamp:       current.LastChecked,
+	}
+	if previous == nil {
+		return event
+	}
+
+	event.From = previous.Status
+	if previous.Status != current.Status {
+		return event
+	}
+	if current.Status == StatusMismatch &&
+		(previous.Message != current.Message || previous.LiveFingerprint != current.LiveFingerprint) {
+		return event
+	}
+	return nil
+}
+
+// NewRemovalEvent returns the event recording that a pod and its last result were removed
+func NewRemovalEvent(previous *ScanResult, at time.Time) HistoryEvent {
+	return HistoryEvent{
+		ClusterName:  previous.ClusterName,
+		PodNamespace: previous.PodNamespace,
+		PodName:      previous.PodName,
+		ServiceName:  previous.ServiceName,
+		KubeService:  previous.KubeService,
+		From:         previous.Status,
+		Message:      "Pod removed",
+		BSRModule:    previous.BSRModule,
+		BSRCommit:    previous.BSRCommit,
+		Timestamp:    at,
+	}
+}
+
+// ResultKey returns the key of the result the event was recorded for
+func (e HistoryEvent) ResultKey() ResultKey {
+	return ResultKey{
+		ClusterName: e.ClusterName,
+		Namespace:   e.PodNamespace,
+		PodName:     e.PodName,
+		KubeService: e.KubeService,
+	}
+}
+
+// Removed reports whether the event records the removal of the pod
+func (e HistoryEvent) Removed() bool {
+	return e.To == ""
+}
+
+// HistoryQuery selects history events. Empty fields match everything.
+type HistoryQuery struct {
+	ClusterName string
+	Namespace   string
+	ServiceName string
+	PodName     string
+	// Since drops events older than this time
+	Since time.Time
+	// Limit caps the number of events returned, newest first (0 means no limit)
+	Limit int
+}
+
+// Matches reports whether an event is selected by the query
+func (q HistoryQuery) Matches(event HistoryEvent) bool {
+	return (q.ClusterName == "" || event.ClusterName == q.ClusterName) &&
+		(q.Namespace == "" || event.PodNamespace == q.Namespace) &&
+		(q.ServiceName == "" || event.ServiceName == q.ServiceName) &&
+		(q.PodName == "" || event.PodName == q.PodName) &&
+		!event.Timestamp.Before(q.Since)
+}
+
+// StatusPeriod is a span of time during which a service had the same aggregated status
+type StatusPeriod struct {
+	Status DiffStatus `json:"status"`
+	Start  time.Time  `json:"start"`
+	End    time.Time  `json:"end"`
+}
+
+// Duration returns the length of the period
+func (p StatusPeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// ServiceTimeline replays the history events of a service and returns the
+// periods of its aggregated status between since and until. The service is
+// MISMATCH while any pod drifted, UNKNOWN while any pod could not be validated
+// or no pod is known, and SYNC otherwise. Events before since only establish
+// the initial state.
+func ServiceTimeline(events []HistoryEvent, since, until time.Time) []StatusPeriod {
+	ordered := append([]HistoryEvent(nil), events...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp.Before(ordered[j].Timestamp)
+	})
+
+	podStatus := make(map[string]DiffStatus)
+	var periods []StatusPeriod
+	for _, event := range ordered {
+		if event.Timestamp.After(until) {
+			break
+		}
+		podKey := event.ResultKey().String()
+		if event.Removed() {
+			delete(podStatus, podKey)
+		} else {
+			podStatus[podKey] = event.To
+		}
+		status := aggregateStatus(podStatus)
+
+		start := event.Timestamp
+		if start.Before(since) {
+			start = since
+		}
+		if n := len(periods); n > 0 {
+			if periods[n-1].Status == status {
+				continue
+			}
+			periods[n-1].End = start
+			if !periods[n-1].End.After(periods[n-1].Start) {
+				periods = periods[:n-1]
+				if n > 1 && periods[n-2].Status == status {
+					continue
+				}
+			}
+		}
+		periods = append(periods, StatusPeriod{Status: status, Start: start})
+	}
+
+	if n := len(periods); n > 0 {
+		periods[n-1].End = until
+	}
+	return periods
+}
+
+// TimeInStatus returns the total time the periods spent in a status
+func TimeInStatus(periods []StatusPeriod, status DiffStatus) time.Duration {
+	var total time.Duration
+	for _, period := range periods {
+		if period.Status == status {
+			total += period.Duration()
+		}
+	}
+	return total
+}
+
+// aggregateStatus combines pod statuses into a service status
+func aggregateStatus(podStatus map[string]DiffStatus) DiffStatus {
+	if len(podStatus) == 0 {
+		return StatusUnknown
+	}
+	status := StatusSync
+	for _, s := range podStatus {
+		switch s {
+		case StatusMismatch:
+			return StatusMismatch
+		case StatusUnknown:
+			status = StatusUnknown
+		}
+	}
+	return status
+}
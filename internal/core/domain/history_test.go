@@ -0,0 +1,219 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// historyResult builds the result of a pod of the users service
+func historyResult(pod string, status DiffStatus, message string) *ScanResult {
+	return &ScanResult{
+		ClusterName:     "default",
+		PodNamespace:    "prod",
+		PodName:         pod,
+		ServiceName:     "users",
+		Status:          status,
+		Message:         message,
+		LiveFingerprint: "live-1",
+		LastChecked:     time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNewHistoryEvent(t *testing.T) {
+	drifted := historyResult("users-a", StatusMismatch, "method removed")
+	tests := []struct {
+		name     string
+		previous *ScanResult
+		current  *ScanResult
+		wantFrom DiffStatus
+		want     bool
+	}{
+		{"first result", nil, historyResult("users-a", StatusSync, ""), "", true},
+		{"unchanged", historyResult("users-a", StatusSync, ""), historyResult("users-a", StatusSync, ""), "", false},
+		{"drift", historyResult("users-a", StatusSync, ""), drifted, StatusSync, true},
+		{"same drift", drifted, historyResult("users-a", StatusMismatch, "method removed"), "", false},
+		{"drift changed", drifted, historyResult("users-a", StatusMismatch, "field removed"), StatusMismatch, true},
+		{"failure message changed", historyResult("users-a", StatusUnknown, "timeout"),
+			historyResult("users-a", StatusUnknown, "connection refused"), "", false},
+		{"recovery", drifted, historyResult("users-a", StatusSync, ""), StatusMismatch, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := NewHistoryEvent(tt.previous, tt.current)
+			if (event != nil) != tt.want {
+				t.Fatalf("NewHistoryEvent() = %+v, want an event: %v", event, tt.want)
+			}
+			if event == nil {
+				return
+			}
+			if event.From != tt.wantFrom || event.To != tt.current.Status || event.Removed() {
+				t.Errorf("event from %q to %q, want from %q to %q", event.From, event.To, tt.wantFrom, tt.current.Status)
+			}
+		})
+	}
+}
+
+func TestNewRemovalEvent(t *testing.T) {
+	result := historyResult("users-a", StatusMismatch, "method removed")
+	result.KubeService = "users-grpc"
+	at := result.LastChecked.Add(time.Hour)
+
+	event := NewRemovalEvent(result, at)
+	if !event.Removed() || event.From != StatusMismatch || !event.Timestamp.Equal(at) {
+		t.Errorf("NewRemovalEvent() = %+v, want a removal from MISMATCH at %s", event, at)
+	}
+	if event.ResultKey() != result.Key() {
+		t.Errorf("ResultKey() = %s, want %s", event.ResultKey(), result.Key())
+	}
+}
+
+func TestHistoryQueryMatches(t *testing.T) {
+	event := *NewHistoryEvent(nil, historyResult("users-a", StatusSync, ""))
+	tests := []struct {
+		name  string
+		query HistoryQuery
+		want  bool
+	}{
+		{"empty", HistoryQuery{}, true},
+		{"all fields", HistoryQuery{ClusterName: "default", Namespace: "prod", ServiceName: "users", PodName: "users-a"}, true},
+		{"other cluster", HistoryQuery{ClusterName: "eu"}, false},
+		{"other namespace", HistoryQuery{Namespace: "staging"}, false},
+		{"other service", HistoryQuery{ServiceName: "orders"}, false},
+		{"other pod", HistoryQuery{PodName: "users-b"}, false},
+		{"since the event", HistoryQuery{Since: event.Timestamp}, true},
+		{"since later", HistoryQuery{Since: event.Timestamp.Add(time.Second)}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.query.Matches(event); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// at returns a time minutes after the start of the test timelines
+func at(minutes int) time.Time {
+	return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+}
+
+// timelineEvent builds the event of a pod changing to a status at a minute;
+// an empty status records the pod's removal
+func timelineEvent(pod string, status DiffStatus, minute int) HistoryEvent {
+	return HistoryEvent{ClusterName: "default", PodNamespace: "prod", PodName: pod, ServiceName: "users", To: status, Timestamp: at(minute)}
+}
+
+func TestServiceTimeline(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []HistoryEvent
+		since  int
+		until  int
+		want   []StatusPeriod
+	}{
+		{"no events", nil, 0, 60, nil},
+		{
+			"drift and recovery",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusSync, 0),
+				timelineEvent("users-a", StatusMismatch, 10),
+				timelineEvent("users-a", StatusSync, 40),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusSync, at(0), at(10)}, {StatusMismatch, at(10), at(40)}, {StatusSync, at(40), at(60)}},
+		},
+		{
+			"one drifted pod drifts the service",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusSync, 0),
+				timelineEvent("users-b", StatusMismatch, 5),
+				timelineEvent("users-b", StatusUnknown, 15),
+				timelineEvent("users-a", StatusSync, 20),
+				timelineEvent("users-b", StatusSync, 30),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusSync, at(0), at(5)}, {StatusMismatch, at(5), at(15)}, {StatusUnknown, at(15), at(30)}, {StatusSync, at(30), at(60)}},
+		},
+		{
+			"removing the drifted pod recovers the service",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusMismatch, 0),
+				timelineEvent("users-b", StatusSync, 10),
+				timelineEvent("users-a", "", 20),
+				timelineEvent("users-b", "", 50),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusMismatch, at(0), at(20)}, {StatusSync, at(20), at(50)}, {StatusUnknown, at(50), at(60)}},
+		},
+		{
+			"events before since set the initial state",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusSync, -120),
+				timelineEvent("users-a", StatusMismatch, -60),
+				timelineEvent("users-a", StatusSync, 30),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusMismatch, at(0), at(30)}, {StatusSync, at(30), at(60)}},
+		},
+		{
+			"events after until are ignored",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusSync, 0),
+				timelineEvent("users-a", StatusMismatch, 90),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusSync, at(0), at(60)}},
+		},
+		{
+			"unordered events",
+			[]HistoryEvent{
+				timelineEvent("users-a", StatusSync, 30),
+				timelineEvent("users-a", StatusMismatch, 10),
+			},
+			0, 60,
+			[]StatusPeriod{{StatusMismatch, at(10), at(30)}, {StatusSync, at(30), at(60)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ServiceTimeline(tt.events, at(tt.since), at(tt.until))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ServiceTimeline() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Status != tt.want[i].Status || !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("period %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTimeInStatus(t *testing.T) {
+	periods := []StatusPeriod{
+		{StatusSync, at(0), at(10)},
+		{StatusMismatch, at(10), at(40)},
+		{StatusSync, at(40), at(60)},
+	}
+	if got := TimeInStatus(periods, StatusSync); got != 30*time.Minute {
+		t.Errorf("TimeInStatus(SYNC) = %s, want 30m", got)
+	}
+	if got := TimeInStatus(periods, StatusMismatch); got != 30*time.Minute {
+		t.Errorf("TimeInStatus(MISMATCH) = %s, want 30m", got)
+	}
+	if got := TimeInStatus(periods, StatusUnknown); got != 0 {
+		t.Errorf("TimeInStatus(UNKNOWN) = %s, want 0", got)
+	}
+}
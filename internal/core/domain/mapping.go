@@ -25,6 +25,8 @@
 // logic without dependencies on infrastructure concerns (HTTP, Kubernetes, etc).
 package domain
 
+import "strings"
+
 // ServiceMapping represents the configuration mapping between a service and its BSR module
 type ServiceMapping struct {
 	// ServiceName is the logical service identifier
@@ -84,3 +86,20 @@ func (sm ServiceMappings) GetServiceNames() []string {
 	}
 	return names
 }
+
+// BSRModuleURL returns the web page of a BSR module (e.g. buf.build/acme/user
+// or buf.build/acme/user:main) at a commit, or at the module's reference when
+// the commit is unknown
+func BSRModuleURL(module, commit string) string {
+	if module == "" {
+		return ""
+	}
+	name, ref, _ := strings.Cut(module, ":")
+	if commit != "" {
+		ref = commit
+	}
+	if ref == "" {
+		return "https://" + name
+	}
+	return "https://" + name + "/docs/" + ref
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+// ScanProgress reports how far the current (or last) scan cycle has come
+type ScanProgress struct {
+	// Running is true while the cycle is in progress
+	Running bool `json:"running"`
+	// Cluster is the cluster being scanned
+	Cluster string `json:"cluster,omitempty"`
+	// ClustersDone and ClusterCount count the clusters scanned so far and in total
+	ClustersDone int `json:"clusters_done"`
+	ClusterCount int `json:"cluster_count"`
+	// PodsScanned and PodCount count the pods scanned so far and the pods
+	// discovered in the clusters scanned so far
+	PodsScanned int        `json:"pods_scanned"`
+	PodCount    int        `json:"pod_count"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
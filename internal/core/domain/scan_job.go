@@ -0,0 +1,70 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "time"
+
+// ScanState is the progress of an on-demand scan
+type ScanState string
+
+const (
+	// ScanQueued means the scan waits for the scanner to pick it up
+	ScanQueued ScanState = "queued"
+	// ScanRunning means the selected pods are being validated
+	ScanRunning ScanState = "running"
+	// ScanFinished means all selected pods were validated
+	ScanFinished ScanState = "finished"
+	// ScanFailed means the scan could not discover the selected pods
+	ScanFailed ScanState = "failed"
+)
+
+// ScanRequest selects the pods an on-demand scan validates. Empty fields match
+// everything, so an empty request rescans every cluster.
+type ScanRequest struct {
+	ClusterName string `json:"cluster,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	ServiceName string `json:"service,omitempty"`
+	PodName     string `json:"pod,omitempty"`
+}
+
+// Matches reports whether a pod is selected by the request
+func (r ScanRequest) Matches(clusterName, namespace, serviceName, podName string) bool {
+	return (r.ClusterName == "" || r.ClusterName == clusterName) &&
+		(r.Namespace == "" || r.Namespace == namespace) &&
+		(r.ServiceName == "" || r.ServiceName == serviceName) &&
+		(r.PodName == "" || r.PodName == podName)
+}
+
+// ScanJob tracks an on-demand scan
+type ScanJob struct {
+	ID      string      `json:"id"`
+	Request ScanRequest `json:"request"`
+	State   ScanState   `json:"state"`
+	// Error explains why a scan failed, or which clusters could not be scanned
+	Error string `json:"error,omitempty"`
+	// PodCount is the number of pods validated so far
+	PodCount      int        `json:"pod_count"`
+	SyncCount     int        `json:"sync_count"`
+	MismatchCount int        `json:"mismatch_count"`
+	UnknownCount  int        `json:"unknown_count"`
+	QueuedAt      time.Time  `json:"queued_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// Done reports whether the scan has finished or failed
+func (j ScanJob) Done() bool {
+	return j.State == ScanFinished || j.State == ScanFailed
+}
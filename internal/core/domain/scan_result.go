@@ -18,14 +18,30 @@ import "time"
 
 // ScanResult represents the validation result for a single pod
 type ScanResult struct {
+	// ClusterName identifies the Kubernetes cluster the pod runs in
+	ClusterName string `json:"cluster_name"`
 	// PodName is the Kubernetes pod name
 	PodName string `json:"pod_name"`
 	// PodNamespace is the Kubernetes namespace
 	PodNamespace string `json:"pod_namespace"`
 	// ServiceName is the logical service name (from labels)
 	ServiceName string `json:"service_name"`
+	// Workload is the controller owning the pod (nil for bare pods)
+	Workload *WorkloadRef `json:"workload,omitempty"`
+	// KubeService is the Kubernetes Service the pod was reached through (service discovery mode)
+	KubeService string `json:"kube_service,omitempty"`
+	// Binding is the namespace/name of the SchemaBinding that selected the pod
+	Binding string `json:"binding,omitempty"`
+	// Owner is the team or person owning the service (protodiff.io/owner annotation)
+	Owner string `json:"owner,omitempty"`
 	// BSRModule is the Buf Schema Registry module reference
 	BSRModule string `json:"bsr_module"`
+	// BSRCommit is the BSR commit the module resolved to when compared (if known)
+	BSRCommit string `json:"bsr_commit,omitempty"`
+	// Image is the container image reference serving gRPC
+	Image string `json:"image,omitempty"`
+	// ImageDigest is the resolved digest of the container image
+	ImageDigest string `json:"image_digest,omitempty"`
 	// Status indicates the drift detection status
 	Status DiffStatus `json:"status"`
 	// Message provides additional context (error message, etc.)
@@ -38,6 +54,61 @@ type ScanResult struct {
 	PodIP string `json:"pod_ip"`
 	// GRPCPort is the port used for gRPC reflection
 	GRPCPort int32 `json:"grpc_port"`
+	// PortSource is the detection rule that selected GRPCPort
+	PortSource PortSource `json:"port_source,omitempty"`
+	// LiveFingerprint identifies the live schema (services and methods) served by the pod
+	LiveFingerprint string `json:"live_fingerprint,omitempty"`
+	// LiveSnapshot is the ID of the stored FileDescriptorSet served by the pod
+	LiveSnapshot string `json:"live_snapshot,omitempty"`
+	// BSRSnapshot is the ID of the stored FileDescriptorSet fetched from BSR
+	BSRSnapshot string `json:"bsr_snapshot,omitempty"`
+	// ScannedBy identifies the ProtoDiff replica that produced the result
+	ScannedBy string `json:"scanned_by,omitempty"`
+}
+
+// ResultKey identifies a stored result. In service discovery mode a pod behind
+// several Services has a result per Service, told apart by KubeService.
+type ResultKey struct {
+	ClusterName string
+	Namespace   string
+	PodName     string
+	// KubeService is the Service the pod was reached through ("" in pod discovery mode)
+	KubeService string
+}
+
+// String renders the key as cluster/namespace/pod, followed by /service in
+// service discovery mode
+func (k ResultKey) String() string {
+	key := k.ClusterName + "/" + k.Namespace + "/" + k.PodName
+	if k.KubeService != "" {
+		key += "/" + k.KubeService
+	}
+	return key
+}
+
+// Key returns the key the result is stored under
+func (r *ScanResult) Key() ResultKey {
+	return ResultKey{
+		ClusterName: r.ClusterName,
+		Namespace:   r.PodNamespace,
+		PodName:     r.PodName,
+		KubeService: r.KubeService,
+	}
+}
+
+// ImageRecord correlates a container image build with the schema it served
+type ImageRecord struct {
+	ClusterName string `json:"cluster_name"`
+	ServiceName string `json:"service_name"`
+	Image       string `json:"image"`
+	ImageDigest string `json:"image_digest"`
+	// LiveFingerprint identifies the schema served by pods running this image
+	LiveFingerprint string `json:"live_fingerprint,omitempty"`
+	// BSRCommit is the BSR commit the image was last compared against
+	BSRCommit string     `json:"bsr_commit,omitempty"`
+	Status    DiffStatus `json:"status"`
+	FirstSeen time.Time  `json:"first_seen"`
+	LastSeen  time.Time  `json:"last_seen"`
 }
 
 // SchemaDiff contains detailed diff information between live and BSR schemas
@@ -14,12 +14,21 @@
 
 package domain
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
 // SchemaDescriptor represents a protobuf schema definition
 type SchemaDescriptor struct {
 	// Services is a list of gRPC service definitions
 	Services []ServiceDescriptor `json:"services"`
 	// Messages is a list of message type definitions
 	Messages []string `json:"messages"`
+	// FileDescriptorSet is the serialized schema the descriptor was built from (if available)
+	FileDescriptorSet []byte `json:"-"`
 }
 
 // ServiceDescriptor represents a single gRPC service definition
@@ -29,3 +38,37 @@ type ServiceDescriptor struct {
 	// Methods is a list of RPC method names
 	Methods []string `json:"methods"`
 }
+
+// Fingerprint returns a stable hash of the services and methods in the schema.
+// Two schemas exposing the same services and methods share a fingerprint,
+// regardless of the order reflection returned them in.
+func (sd *SchemaDescriptor) Fingerprint() string {
+	if sd == nil {
+		return ""
+	}
+
+	services := make([]string, 0, len(sd.Services))
+	for _, svc := range sd.Services {
+		methods := append([]string(nil), svc.Methods...)
+		sort.Strings(methods)
+		services = append(services, svc.Name+"("+strings.Join(methods, ",")+")")
+	}
+	sort.Strings(services)
+
+	hash := sha256.New()
+	for _, svc := range services {
+		hash.Write([]byte(svc))
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12]
+}
+
+// SnapshotID returns the content-addressed ID of a serialized FileDescriptorSet.
+// Identical schema snapshots share an ID and are stored once.
+func SnapshotID(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
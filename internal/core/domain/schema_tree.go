@@ -0,0 +1,168 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+// Kinds of schema declarations
+const (
+	DeclService = "service"
+	DeclMessage = "message"
+	DeclEnum    = "enum"
+)
+
+// SchemaDecl is a top-level declaration of a schema: a service, message or enum
+type SchemaDecl struct {
+	Kind string `json:"kind"`
+	// Name is the fully qualified name
+	Name string `json:"name"`
+	// Members are the methods, fields or enum values, in declaration order
+	Members []SchemaMember `json:"members"`
+}
+
+// SchemaMember is a method, field or enum value of a declaration
+type SchemaMember struct {
+	Name string `json:"name"`
+	// Declaration renders the member as in .proto source, e.g. "repeated string tags = 2"
+	Declaration string `json:"declaration"`
+}
+
+// SchemaChange tells how a node of the schema tree differs between live and BSR
+type SchemaChange string
+
+const (
+	// SchemaUnchanged means live and BSR declare the node identically
+	SchemaUnchanged SchemaChange = ""
+	// SchemaLiveOnly means the node is served by the pod but not defined in BSR
+	SchemaLiveOnly SchemaChange = "live-only"
+	// SchemaBSROnly means the node is defined in BSR but not served by the pod
+	SchemaBSROnly SchemaChange = "bsr-only"
+	// SchemaChanged means the node exists on both sides but differs
+	SchemaChanged SchemaChange = "changed"
+)
+
+// SchemaTreeNode is a node of the side-by-side schema tree: a declaration with
+// its members as children, or a member
+type SchemaTreeNode struct {
+	Kind string
+	Name string
+	// Live and BSR are the node's declaration on each side, empty if absent
+	Live   string
+	BSR    string
+	Change SchemaChange
+	// Children are the members of a declaration
+	Children []SchemaTreeNode
+}
+
+// CompareSchemaDecls merges the declarations of the live and BSR schemas into
+// a tree, marking what was added, removed or changed. Declarations and members
+// keep the live order, followed by those only defined in BSR.
+func CompareSchemaDecls(live, bsr []SchemaDecl) []SchemaTreeNode {
+	bsrDecls := make(map[string]SchemaDecl, len(bsr))
+	for _, decl := range bsr {
+		bsrDecls[decl.Kind+" "+decl.Name] = decl
+	}
+
+	nodes := make([]SchemaTreeNode, 0, len(live))
+	seen := make(map[string]bool, len(live))
+	for _, decl := range live {
+		key := decl.Kind + " " + decl.Name
+		seen[key] = true
+		if other, ok := bsrDecls[key]; ok {
+			nodes = append(nodes, compareDecl(decl, other))
+		} else {
+			nodes = append(nodes, oneSidedDecl(decl, SchemaLiveOnly))
+		}
+	}
+	for _, decl := range bsr {
+		if !seen[decl.Kind+" "+decl.Name] {
+			nodes = append(nodes, oneSidedDecl(decl, SchemaBSROnly))
+		}
+	}
+	return nodes
+}
+
+// compareDecl compares the members of a declaration present on both sides
+func compareDecl(live, bsr SchemaDecl) SchemaTreeNode {
+	node := SchemaTreeNode{Kind: live.Kind, Name: live.Name, Live: live.Name, BSR: bsr.Name}
+
+	bsrMembers := make(map[string]string, len(bsr.Members))
+	for _, member := range bsr.Members {
+		bsrMembers[member.Name] = member.Declaration
+	}
+	seen := make(map[string]bool, len(live.Members))
+	for _, member := range live.Members {
+		seen[member.Name] = true
+		child := SchemaTreeNode{Name: member.Name, Live: member.Declaration}
+		if declaration, ok := bsrMembers[member.Name]; !ok {
+			child.Change = SchemaLiveOnly
+		} else {
+			child.BSR = declaration
+			if declaration != member.Declaration {
+				child.Change = SchemaChanged
+			}
+		}
+		node.Children = append(node.Children, child)
+	}
+	for _, member := range bsr.Members {
+		if !seen[member.Name] {
+			node.Children = append(node.Children, SchemaTreeNode{Name: member.Name, BSR: member.Declaration, Change: SchemaBSROnly})
+		}
+	}
+
+	for _, child := range node.Children {
+		if child.Change != SchemaUnchanged {
+			node.Change = SchemaChanged
+			break
+		}
+	}
+	return node
+}
+
+// oneSidedDecl builds the node of a declaration present on one side only
+func oneSidedDecl(decl SchemaDecl, change SchemaChange) SchemaTreeNode {
+	node := SchemaTreeNode{Kind: decl.Kind, Name: decl.Name, Change: change}
+	for _, member := range decl.Members {
+		child := SchemaTreeNode{Name: member.Name, Change: change}
+		if change == SchemaLiveOnly {
+			child.Live = member.Declaration
+		} else {
+			child.BSR = member.Declaration
+		}
+		node.Children = append(node.Children, child)
+	}
+	if change == SchemaLiveOnly {
+		node.Live = decl.Name
+	} else {
+		node.BSR = decl.Name
+	}
+	return node
+}
+
+// CountSchemaChanges counts the changed declarations and members of a tree by kind of change
+func CountSchemaChanges(nodes []SchemaTreeNode) map[SchemaChange]int {
+	counts := make(map[SchemaChange]int)
+	for _, node := range nodes {
+		if node.Change != SchemaUnchanged && node.Change != SchemaChanged {
+			// A declaration missing on one side counts once, not per member
+			counts[node.Change]++
+			continue
+		}
+		for _, child := range node.Children {
+			if child.Change != SchemaUnchanged {
+				counts[child.Change]++
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// member builds a schema member from its name and declaration
+func member(name, declaration string) SchemaMember {
+	return SchemaMember{Name: name, Declaration: declaration}
+}
+
+func TestCompareSchemaDecls(t *testing.T) {
+	live := []SchemaDecl{
+		{Kind: DeclService, Name: "acme.UserService", Members: []SchemaMember{
+			member("GetUser", "rpc GetUser(acme.GetUserRequest) returns (acme.User)"),
+			member("DeleteUser", "rpc DeleteUser(acme.DeleteUserRequest) returns (acme.User)"),
+		}},
+		{Kind: DeclMessage, Name: "acme.User", Members: []SchemaMember{
+			member("id", "string id = 1"),
+			member("tags", "repeated string tags = 2"),
+		}},
+		{Kind: DeclMessage, Name: "acme.DeleteUserRequest", Members: []SchemaMember{
+			member("id", "string id = 1"),
+		}},
+	}
+	bsr := []SchemaDecl{
+		{Kind: DeclService, Name: "acme.UserService", Members: []SchemaMember{
+			member("GetUser", "rpc GetUser(acme.GetUserRequest) returns (acme.User)"),
+			member("ListUsers", "rpc ListUsers(acme.ListUsersRequest) returns (stream acme.User)"),
+		}},
+		{Kind: DeclMessage, Name: "acme.User", Members: []SchemaMember{
+			member("id", "string id = 1"),
+			member("tags", "string tags = 2"),
+		}},
+		{Kind: DeclEnum, Name: "acme.Role", Members: []SchemaMember{
+			member("ROLE_UNSPECIFIED", "ROLE_UNSPECIFIED = 0"),
+		}},
+	}
+
+	want := []SchemaTreeNode{
+		{Kind: DeclService, Name: "acme.UserService", Live: "acme.UserService", BSR: "acme.UserService", Change: SchemaChanged, Children: []SchemaTreeNode{
+			{Name: "GetUser", Live: "rpc GetUser(acme.GetUserRequest) returns (acme.User)", BSR: "rpc GetUser(acme.GetUserRequest) returns (acme.User)"},
+			{Name: "DeleteUser", Live: "rpc DeleteUser(acme.DeleteUserRequest) returns (acme.User)", Change: SchemaLiveOnly},
+			{Name: "ListUsers", BSR: "rpc ListUsers(acme.ListUsersRequest) returns (stream acme.User)", Change: SchemaBSROnly},
+		}},
+		{Kind: DeclMessage, Name: "acme.User", Live: "acme.User", BSR: "acme.User", Change: SchemaChanged, Children: []SchemaTreeNode{
+			{Name: "id", Live: "string id = 1", BSR: "string id = 1"},
+			{Name: "tags", Live: "repeated string tags = 2", BSR: "string tags = 2", Change: SchemaChanged},
+		}},
+		{Kind: DeclMessage, Name: "acme.DeleteUserRequest", Live: "acme.DeleteUserRequest", Change: SchemaLiveOnly, Children: []SchemaTreeNode{
+			{Name: "id", Live: "string id = 1", Change: SchemaLiveOnly},
+		}},
+		{Kind: DeclEnum, Name: "acme.Role", BSR: "acme.Role", Change: SchemaBSROnly, Children: []SchemaTreeNode{
+			{Name: "ROLE_UNSPECIFIED", BSR: "ROLE_UNSPECIFIED = 0", Change: SchemaBSROnly},
+		}},
+	}
+	got := CompareSchemaDecls(live, bsr)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareSchemaDecls() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	counts := CountSchemaChanges(got)
+	wantCounts := map[SchemaChange]int{SchemaLiveOnly: 2, SchemaBSROnly: 2, SchemaChanged: 1}
+	if !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("CountSchemaChanges() = %v, want %v", counts, wantCounts)
+	}
+}
+
+func TestCompareSchemaDeclsIdentical(t *testing.T) {
+	decls := []SchemaDecl{
+		{Kind: DeclMessage, Name: "acme.User", Members: []SchemaMember{member("id", "string id = 1")}},
+		// A message and an enum may share a name without being compared
+		{Kind: DeclEnum, Name: "acme.Kind", Members: []SchemaMember{member("KIND_UNSPECIFIED", "KIND_UNSPECIFIED = 0")}},
+	}
+	nodes := CompareSchemaDecls(decls, decls)
+	if len(nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(nodes))
+	}
+	for _, node := range nodes {
+		if node.Change != SchemaUnchanged {
+			t.Errorf("node %s %s changed: %q", node.Kind, node.Name, node.Change)
+		}
+	}
+	if counts := CountSchemaChanges(nodes); len(counts) != 0 {
+		t.Errorf("CountSchemaChanges() = %v, want no changes", counts)
+	}
+
+	// The same name with another kind is a different declaration
+	renamed := []SchemaDecl{{Kind: DeclMessage, Name: "acme.Kind"}}
+	nodes = CompareSchemaDecls(renamed, decls[1:])
+	if len(nodes) != 2 || nodes[0].Change != SchemaLiveOnly || nodes[1].Change != SchemaBSROnly {
+		t.Errorf("CompareSchemaDecls() = %+v, want a live-only message and a BSR-only enum", nodes)
+	}
+}
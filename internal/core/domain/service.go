@@ -0,0 +1,111 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ServiceSummary aggregates scan results of all pods of a service in a cluster
+type ServiceSummary struct {
+	ClusterName string `json:"cluster_name"`
+	ServiceName string `json:"service_name"`
+	// Namespaces lists the namespaces the service's pods run in, sorted
+	Namespaces []string `json:"namespaces"`
+	// BSRModules lists the BSR modules the pods were compared against, sorted
+	BSRModules []string `json:"bsr_modules,omitempty"`
+	// Status is MISMATCH if any pod drifted, UNKNOWN if any pod could not be
+	// validated, and SYNC only when all pods are in sync
+	Status        DiffStatus `json:"status"`
+	PodCount      int        `json:"pod_count"`
+	SyncCount     int        `json:"sync_count"`
+	MismatchCount int        `json:"mismatch_count"`
+	UnknownCount  int        `json:"unknown_count"`
+	// LastChecked is the most recent validation of any of the pods
+	LastChecked time.Time `json:"last_checked"`
+}
+
+// AggregateServices groups scan results by cluster and service name
+func AggregateServices(results []*ScanResult) []ServiceSummary {
+	summaries := make(map[string]*ServiceSummary)
+	namespaces := make(map[string]map[string]bool)
+	modules := make(map[string]map[string]bool)
+
+	for _, result := range results {
+		key := result.ClusterName + "/" + result.ServiceName
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &ServiceSummary{
+				ClusterName: result.ClusterName,
+				ServiceName: result.ServiceName,
+			}
+			summaries[key] = summary
+			namespaces[key] = make(map[string]bool)
+			modules[key] = make(map[string]bool)
+		}
+
+		summary.PodCount++
+		switch result.Status {
+		case StatusSync:
+			summary.SyncCount++
+		case StatusMismatch:
+			summary.MismatchCount++
+		default:
+			summary.UnknownCount++
+		}
+		if result.LastChecked.After(summary.LastChecked) {
+			summary.LastChecked = result.LastChecked
+		}
+		namespaces[key][result.PodNamespace] = true
+		if result.BSRModule != "" {
+			modules[key][result.BSRModule] = true
+		}
+	}
+
+	list := make([]ServiceSummary, 0, len(summaries))
+	for key, summary := range summaries {
+		summary.Namespaces = sortedKeys(namespaces[key])
+		summary.BSRModules = sortedKeys(modules[key])
+
+		switch {
+		case summary.MismatchCount > 0:
+			summary.Status = StatusMismatch
+		case summary.UnknownCount > 0:
+			summary.Status = StatusUnknown
+		default:
+			summary.Status = StatusSync
+		}
+		list = append(list, *summary)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ClusterName != list[j].ClusterName {
+			return list[i].ClusterName < list[j].ClusterName
+		}
+		return list[i].ServiceName < list[j].ServiceName
+	})
+	return list
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
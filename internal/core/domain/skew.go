@@ -0,0 +1,102 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import "sort"
+
+// ClusterSkew reports a service whose live schema differs between clusters
+type ClusterSkew struct {
+	// ServiceName is the logical service name
+	ServiceName string `json:"service_name"`
+	// Variants lists each distinct live schema per cluster
+	Variants []ClusterSchemaVariant `json:"variants"`
+}
+
+// ClusterSchemaVariant describes one live schema version of a service in a cluster
+type ClusterSchemaVariant struct {
+	ClusterName string     `json:"cluster_name"`
+	Fingerprint string     `json:"fingerprint"`
+	Status      DiffStatus `json:"status"`
+	PodCount    int        `json:"pod_count"`
+}
+
+// DetectClusterSkew finds services that serve different live schemas in different clusters.
+// Results without a live fingerprint (e.g. unreachable pods) are ignored.
+// Services deployed to a single cluster never report skew.
+func DetectClusterSkew(results []*ScanResult) []ClusterSkew {
+	type variantKey struct {
+		cluster     string
+		fingerprint string
+	}
+
+	variantsByService := make(map[string]map[variantKey]*ClusterSchemaVariant)
+	for _, result := range results {
+		if result.LiveFingerprint == "" {
+			continue
+		}
+
+		variants, ok := variantsByService[result.ServiceName]
+		if !ok {
+			variants = make(map[variantKey]*ClusterSchemaVariant)
+			variantsByService[result.ServiceName] = variants
+		}
+
+		key := variantKey{cluster: result.ClusterName, fingerprint: result.LiveFingerprint}
+		variant, ok := variants[key]
+		if !ok {
+			variant = &ClusterSchemaVariant{
+				ClusterName: result.ClusterName,
+				Fingerprint: result.LiveFingerprint,
+				Status:      result.Status,
+			}
+			variants[key] = variant
+		}
+		variant.PodCount++
+		// A single drifted pod marks the whole variant as drifted
+		if result.Status == StatusMismatch {
+			variant.Status = StatusMismatch
+		}
+	}
+
+	var skews []ClusterSkew
+	for serviceName, variants := range variantsByService {
+		clusters := make(map[string]bool)
+		fingerprints := make(map[string]bool)
+		for key := range variants {
+			clusters[key.cluster] = true
+			fingerprints[key.fingerprint] = true
+		}
+		if len(clusters) < 2 || len(fingerprints) < 2 {
+			continue
+		}
+
+		skew := ClusterSkew{ServiceName: serviceName}
+		for _, variant := range variants {
+			skew.Variants = append(skew.Variants, *variant)
+		}
+		sort.Slice(skew.Variants, func(i, j int) bool {
+			if skew.Variants[i].ClusterName != skew.Variants[j].ClusterName {
+				return skew.Variants[i].ClusterName < skew.Variants[j].ClusterName
+			}
+			return skew.Variants[i].Fingerprint < skew.Variants[j].Fingerprint
+		})
+		skews = append(skews, skew)
+	}
+
+	sort.Slice(skews, func(i, j int) bool {
+		return skews[i].ServiceName < skews[j].ServiceName
+	})
+	return skews
+}
@@ -14,6 +14,8 @@
 
 package domain
 
+import "strings"
+
 // DiffStatus represents the schema comparison status between live pod and BSR
 type DiffStatus string
 
@@ -25,3 +27,29 @@ const (
 	// StatusUnknown indicates the status could not be determined
 	StatusUnknown DiffStatus = "UNKNOWN"
 )
+
+// ParseStatus parses a status name case-insensitively
+func ParseStatus(name string) (DiffStatus, bool) {
+	switch status := DiffStatus(strings.ToUpper(name)); status {
+	case StatusSync, StatusMismatch, StatusUnknown:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+// PortSource identifies the rule used to detect a pod's gRPC port
+type PortSource string
+
+const (
+	// PortSourceAnnotation means the port came from the protodiff.io/port annotation
+	PortSourceAnnotation PortSource = "annotation"
+	// PortSourcePortName means a container or Service port was named "grpc" or "grpc-*"
+	PortSourcePortName PortSource = "port-name"
+	// PortSourceAppProtocol means a Service port with appProtocol "grpc" targets the pod
+	PortSourceAppProtocol PortSource = "app-protocol"
+	// PortSourceProbe means the port was found by probing candidates for reflection
+	PortSourceProbe PortSource = "probe"
+	// PortSourceDefault means no rule matched and the first candidate port was used
+	PortSourceDefault PortSource = "default"
+)
@@ -0,0 +1,164 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"sort"
+	"strconv"
+)
+
+// WorkloadRef identifies the controller that owns a pod
+type WorkloadRef struct {
+	// Kind is the workload kind (Deployment, StatefulSet, DaemonSet, ReplicaSet)
+	Kind string `json:"kind"`
+	// Name is the workload name
+	Name string `json:"name"`
+	// Revision identifies the pod template revision: the ReplicaSet name for
+	// Deployments, the controller revision hash for StatefulSets and DaemonSets
+	Revision string `json:"revision,omitempty"`
+	// RevisionNumber is the Deployment revision number of the ReplicaSet, if known
+	RevisionNumber int64 `json:"revision_number,omitempty"`
+	// UID is the Kubernetes UID of the workload object
+	UID string `json:"uid,omitempty"`
+}
+
+// WorkloadSummary aggregates scan results of all replicas of a workload
+type WorkloadSummary struct {
+	ClusterName string      `json:"cluster_name"`
+	Namespace   string      `json:"namespace"`
+	ServiceName string      `json:"service_name"`
+	Workload    WorkloadRef `json:"workload"`
+	// Status is MISMATCH if any replica drifted, UNKNOWN if any replica could
+	// not be validated, and SYNC only when all replicas are in sync
+	Status        DiffStatus `json:"status"`
+	Replicas      int        `json:"replicas"`
+	SyncCount     int        `json:"sync_count"`
+	MismatchCount int        `json:"mismatch_count"`
+	UnknownCount  int        `json:"unknown_count"`
+	// Revisions breaks replicas down by pod template revision, oldest first
+	Revisions []RevisionSummary `json:"revisions"`
+	// DriftRevision is the oldest revision with drifted replicas, i.e. the
+	// revision that introduced the drift (empty when in sync)
+	DriftRevision string `json:"drift_revision,omitempty"`
+}
+
+// RevisionSummary counts replicas of a single workload revision
+type RevisionSummary struct {
+	Revision       string `json:"revision"`
+	RevisionNumber int64  `json:"revision_number,omitempty"`
+	Replicas       int    `json:"replicas"`
+	MismatchCount  int    `json:"mismatch_count"`
+}
+
+// AggregateWorkloads groups scan results by owning workload.
+// Results for pods without a workload (bare pods) are left out.
+func AggregateWorkloads(results []*ScanResult) []WorkloadSummary {
+	summaries := make(map[string]*WorkloadSummary)
+	revisions := make(map[string]map[string]*RevisionSummary)
+
+	for _, result := range results {
+		if result.Workload == nil {
+			continue
+		}
+
+		key := result.ClusterName + "/" + result.PodNamespace + "/" + result.Workload.Kind + "/" + result.Workload.Name
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &WorkloadSummary{
+				ClusterName: result.ClusterName,
+				Namespace:   result.PodNamespace,
+				ServiceName: result.ServiceName,
+				Workload:    WorkloadRef{Kind: result.Workload.Kind, Name: result.Workload.Name},
+			}
+			summaries[key] = summary
+			revisions[key] = make(map[string]*RevisionSummary)
+		}
+
+		summary.Replicas++
+		switch result.Status {
+		case StatusSync:
+			summary.SyncCount++
+		case StatusMismatch:
+			summary.MismatchCount++
+		default:
+			summary.UnknownCount++
+		}
+
+		revision, ok := revisions[key][result.Workload.Revision]
+		if !ok {
+			revision = &RevisionSummary{
+				Revision:       result.Workload.Revision,
+				RevisionNumber: result.Workload.RevisionNumber,
+			}
+			revisions[key][result.Workload.Revision] = revision
+		}
+		revision.Replicas++
+		if result.Status == StatusMismatch {
+			revision.MismatchCount++
+		}
+	}
+
+	list := make([]WorkloadSummary, 0, len(summaries))
+	for key, summary := range summaries {
+		for _, revision := range revisions[key] {
+			summary.Revisions = append(summary.Revisions, *revision)
+		}
+		sort.Slice(summary.Revisions, func(i, j int) bool {
+			a, b := summary.Revisions[i], summary.Revisions[j]
+			if a.RevisionNumber != b.RevisionNumber {
+				return a.RevisionNumber < b.RevisionNumber
+			}
+			return a.Revision < b.Revision
+		})
+
+		switch {
+		case summary.MismatchCount > 0:
+			summary.Status = StatusMismatch
+		case summary.UnknownCount > 0:
+			summary.Status = StatusUnknown
+		default:
+			summary.Status = StatusSync
+		}
+
+		for _, revision := range summary.Revisions {
+			if revision.MismatchCount > 0 {
+				summary.DriftRevision = revision.Label()
+				break
+			}
+		}
+
+		list = append(list, *summary)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.ClusterName != b.ClusterName {
+			return a.ClusterName < b.ClusterName
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Workload.Name < b.Workload.Name
+	})
+	return list
+}
+
+// Label returns a display label for the revision, e.g. "api-7d9f8 (rev 4)"
+func (r RevisionSummary) Label() string {
+	if r.RevisionNumber == 0 {
+		return r.Revision
+	}
+	return r.Revision + " (rev " + strconv.FormatInt(r.RevisionNumber, 10) + ")"
+}
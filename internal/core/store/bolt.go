@@ -0,0 +1,245 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket names in the BoltDB file
+var (
+	resultsBucket   = []byte("results")
+	imagesBucket    = []byte("images")
+	historyBucket   = []byte("history")
+	snapshotsBucket = []byte("snapshots")
+)
+
+// boltOpenTimeout bounds waiting for the file lock held by another process
+const boltOpenTimeout = 5 * time.Second
+
+// Bolt is a Store persisted to a BoltDB file. Reads are served from memory;
+// every change is written through to the file, which is loaded back on open.
+// Write failures are logged and leave the in-memory state authoritative.
+type Bolt struct {
+	*Memory
+	db *bolt.DB
+
+	// writeMu keeps the file in the same order as memory
+	writeMu sync.Mutex
+}
+
+// NewBolt opens (or creates) the BoltDB file at path and loads its contents
+func NewBolt(path string, opts Options) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store file %s: %w", path, err)
+	}
+
+	s := &Bolt{Memory: NewMemory(opts), db: db}
+	if err := s.load(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load creates the buckets and restores persisted results into memory
+func (s *Bolt) load() error {
+	var results []*domain.ScanResult
+	images := make(map[string][]*domain.ImageRecord)
+	history := make(map[string][]domain.HistoryEvent)
+	snapshots := make(map[string][]byte)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		resultsB, err := tx.CreateBucketIfNotExists(resultsBucket)
+		if err != nil {
+			return err
+		}
+		imagesB, err := tx.CreateBucketIfNotExists(imagesBucket)
+		if err != nil {
+			return err
+		}
+		historyB, err := tx.CreateBucketIfNotExists(historyBucket)
+		if err != nil {
+			return err
+		}
+		snapshotsB, err := tx.CreateBucketIfNotExists(snapshotsBucket)
+		if err != nil {
+			return err
+		}
+
+		if err := resultsB.ForEach(func(key, value []byte) error {
+			var result domain.ScanResult
+			if err := json.Unmarshal(value, &result); err != nil {
+				log.Printf("Warning: Skipping unreadable stored result %s: %v", key, err)
+				return nil
+			}
+			results = append(results, &result)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := imagesB.ForEach(func(key, value []byte) error {
+			var records []*domain.ImageRecord
+			if err := json.Unmarshal(value, &records); err != nil {
+				log.Printf("Warning: Skipping unreadable image history %s: %v", key, err)
+				return nil
+			}
+			images[string(key)] = records
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := historyB.ForEach(func(key, value []byte) error {
+			var events []domain.HistoryEvent
+			if err := json.Unmarshal(value, &events); err != nil {
+				log.Printf("Warning: Skipping unreadable drift history %s: %v", key, err)
+				return nil
+			}
+			history[string(key)] = events
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return snapshotsB.ForEach(func(key, value []byte) error {
+			// Values are only valid during the transaction
+			snapshots[string(key)] = append([]byte(nil), value...)
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load store file: %w", err)
+	}
+
+	s.Memory.restore(results, images, history, snapshots)
+	log.Printf("Loaded %d stored results from %s", len(results), s.db.Path())
+	return nil
+}
+
+// Set stores or updates a scan result and persists it with its service's
+// image history and, when it changed, drift history
+func (s *Bolt) Set(result *domain.ScanResult) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	historyChanged := s.Memory.set(result)
+
+	value, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Warning: Failed to encode result for %s: %v", result.Key(), err)
+		return
+	}
+	images, err := json.Marshal(s.Memory.imageRecords(result.ClusterName, result.ServiceName))
+	if err != nil {
+		log.Printf("Warning: Failed to encode image history for %s: %v", result.ServiceName, err)
+		return
+	}
+
+	var history []byte
+	if historyChanged {
+		history, err = json.Marshal(s.Memory.serviceHistory(result.ClusterName, result.ServiceName))
+		if err != nil {
+			log.Printf("Warning: Failed to encode drift history for %s: %v", result.ServiceName, err)
+			return
+		}
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(resultsBucket).Put([]byte(result.Key().String()), value); err != nil {
+			return err
+		}
+		serviceKey := []byte(result.ClusterName + "/" + result.ServiceName)
+		if err := tx.Bucket(imagesBucket).Put(serviceKey, images); err != nil {
+			return err
+		}
+		if history == nil {
+			return nil
+		}
+		return tx.Bucket(historyBucket).Put(serviceKey, history)
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to persist result: %v", err)
+	}
+}
+
+// Delete removes a scan result from memory and from the file, persisting
+// the removal in the drift history
+func (s *Bolt) Delete(key domain.ResultKey) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	previous := s.Memory.delete(key)
+	if previous == nil {
+		return
+	}
+	history, err := json.Marshal(s.Memory.serviceHistory(previous.ClusterName, previous.ServiceName))
+	if err != nil {
+		log.Printf("Warning: Failed to encode drift history for %s: %v", previous.ServiceName, err)
+		history = nil
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(resultsBucket).Delete([]byte(key.String())); err != nil {
+			return err
+		}
+		if history == nil {
+			return nil
+		}
+		return tx.Bucket(historyBucket).Put([]byte(previous.ClusterName+"/"+previous.ServiceName), history)
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to delete persisted result: %v", err)
+	}
+}
+
+// PutSnapshot stores a schema snapshot in memory and in the file, removing
+// the snapshots pruned from memory from the file as well
+func (s *Bolt) PutSnapshot(id string, data []byte) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	added, pruned := s.Memory.putSnapshot(id, data)
+	if !added {
+		return
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(snapshotsBucket)
+		for _, prunedID := range pruned {
+			if err := bucket.Delete([]byte(prunedID)); err != nil {
+				return err
+			}
+		}
+		return bucket.Put([]byte(id), data)
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to persist schema snapshot %s: %v", id, err)
+	}
+}
+
+// Close closes the BoltDB file
+func (s *Bolt) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// testResult builds the result of a pod of the users service in prod
+func testResult(pod string, status domain.DiffStatus) *domain.ScanResult {
+	return &domain.ScanResult{
+		ClusterName:     "default",
+		PodNamespace:    "prod",
+		PodName:         pod,
+		ServiceName:     "users",
+		Status:          status,
+		Image:           "registry.example.com/users:v1",
+		ImageDigest:     "sha256:abc",
+		LiveFingerprint: "live-1",
+		LastChecked:     time.Now(),
+	}
+}
+
+// storedKeys returns the sorted keys of the results in a store
+func storedKeys(s Store) []string {
+	var keys []string
+	for _, result := range s.GetAll() {
+		keys = append(keys, result.Key().String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// openBolt opens the store file at path, closing it when the test ends
+func openBolt(t *testing.T, path string) *Bolt {
+	t.Helper()
+	s, err := NewBolt(path, Options{})
+	if err != nil {
+		t.Fatalf("NewBolt() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestBoltRestoresAfterReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protodiff.db")
+	s := openBolt(t, path)
+
+	grpcResult := testResult("users-a", domain.StatusSync)
+	grpcResult.KubeService = "users-grpc"
+	adminResult := testResult("users-a", domain.StatusMismatch)
+	adminResult.KubeService = "users-admin"
+	s.Set(grpcResult)
+	s.Set(adminResult)
+	s.Set(testResult("users-b", domain.StatusSync))
+	s.Set(testResult("users-b", domain.StatusMismatch))
+	s.Delete(testResult("users-b", domain.StatusMismatch).Key())
+	s.PutSnapshot("live-1", []byte("live schema"))
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	s = openBolt(t, path)
+	want := []string{"default/prod/users-a/users-admin", "default/prod/users-a/users-grpc"}
+	if got := storedKeys(s); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("restored results = %v, want %v", got, want)
+	}
+	if result, ok := s.Get(adminResult.Key()); !ok || result.Status != domain.StatusMismatch {
+		t.Errorf("Get(%s) = %+v, %v, want the MISMATCH result", adminResult.Key(), result, ok)
+	}
+	if _, ok := s.Get(testResult("users-b", "").Key()); ok {
+		t.Error("deleted result of users-b was restored")
+	}
+	if data, ok := s.GetSnapshot("live-1"); !ok || string(data) != "live schema" {
+		t.Errorf("GetSnapshot(live-1) = %q, %v, want the stored snapshot", data, ok)
+	}
+	if images := s.GetImageHistory(); len(images) != 1 || images[0].ImageDigest != "sha256:abc" {
+		t.Errorf("restored image history = %+v, want the users image", images)
+	}
+
+	// users-a through users-admin and users-grpc, users-b appearing, drifting and removed
+	events := s.History(domain.HistoryQuery{ServiceName: "users"})
+	if len(events) != 5 {
+		t.Fatalf("restored %d history events, want 5: %+v", len(events), events)
+	}
+	if latest := events[0]; latest.PodName != "users-b" || !latest.Removed() {
+		t.Errorf("latest event = %+v, want the removal of users-b", latest)
+	}
+
+	// Deleting after a reopen is persisted as well
+	s.Delete(grpcResult.Key())
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	s = openBolt(t, path)
+	if got := storedKeys(s); len(got) != 1 || got[0] != "default/prod/users-a/users-admin" {
+		t.Errorf("restored results = %v, want only users-a through users-admin", got)
+	}
+}
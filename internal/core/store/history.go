@@ -0,0 +1,98 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// recordHistory appends a history event when the pod's outcome changed and
+// applies the retention limits to the service's history.
+// Callers must hold the write lock.
+func (s *Memory) recordHistory(previous, current *domain.ScanResult) bool {
+	event := domain.NewHistoryEvent(previous, current)
+	if event == nil {
+		return false
+	}
+
+	key := current.ClusterName + "/" + current.ServiceName
+	s.history[key] = s.pruneHistory(append(s.history[key], *event), time.Now())
+	return true
+}
+
+// recordRemoval appends the removal of a pod to its service's history.
+// Callers must hold the write lock.
+func (s *Memory) recordRemoval(previous *domain.ScanResult) {
+	now := time.Now()
+	key := previous.ClusterName + "/" + previous.ServiceName
+	s.history[key] = s.pruneHistory(append(s.history[key], domain.NewRemovalEvent(previous, now)), now)
+}
+
+// pruneHistory drops events older than the retention period, except the latest
+// event of each pod still present, and keeps at most historyLimit events
+func (s *Memory) pruneHistory(events []domain.HistoryEvent, now time.Time) []domain.HistoryEvent {
+	cutoff := now.Add(-s.historyRetention)
+
+	latest := make(map[string]int, len(events))
+	for i, event := range events {
+		latest[event.ResultKey().String()] = i
+	}
+
+	kept := events[:0]
+	for i, event := range events {
+		if event.Timestamp.Before(cutoff) && (event.Removed() || latest[event.ResultKey().String()] != i) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+
+	if len(kept) > s.historyLimit {
+		kept = kept[len(kept)-s.historyLimit:]
+	}
+	return kept
+}
+
+// History returns the recorded events matching the query, newest first
+func (s *Memory) History(query domain.HistoryQuery) []domain.HistoryEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events []domain.HistoryEvent
+	for _, serviceEvents := range s.history {
+		for _, event := range serviceEvents {
+			if query.Matches(event) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	if query.Limit > 0 && len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return events
+}
+
+// serviceHistory returns a copy of the history of a service, oldest first
+func (s *Memory) serviceHistory(clusterName, serviceName string) []domain.HistoryEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.HistoryEvent(nil), s.history[clusterName+"/"+serviceName]...)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// checkedAt returns the result of a pod with the given status validated at a time
+func checkedAt(pod string, status domain.DiffStatus, checked time.Time) *domain.ScanResult {
+	result := testResult(pod, status)
+	result.LastChecked = checked
+	return result
+}
+
+func TestHistoryRetention(t *testing.T) {
+	s := NewMemory(Options{HistoryRetention: time.Hour})
+	now := time.Now()
+
+	// users-a drifted and recovered long ago, users-b only has an old first result
+	s.Set(checkedAt("users-a", domain.StatusSync, now.Add(-3*time.Hour)))
+	s.Set(checkedAt("users-a", domain.StatusMismatch, now.Add(-2*time.Hour)))
+	s.Set(checkedAt("users-b", domain.StatusSync, now.Add(-2*time.Hour)))
+	s.Set(checkedAt("users-c", domain.StatusSync, now.Add(-2*time.Hour)))
+	s.Delete(testResult("users-c", "").Key())
+	s.Set(checkedAt("users-a", domain.StatusSync, now.Add(-30*time.Minute)))
+
+	events := s.History(domain.HistoryQuery{ServiceName: "users"})
+	var got []string
+	for _, event := range events {
+		got = append(got, event.PodName+":"+string(event.From)+">"+string(event.To))
+	}
+	// Old events go, except the latest one of each pod still present; the
+	// removal of users-c, recorded when it happened, is within the retention period
+	want := []string{"users-c:SYNC>", "users-a:MISMATCH>SYNC", "users-b:>SYNC"}
+	if len(got) != len(want) {
+		t.Fatalf("History() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("History() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	s := NewMemory(Options{HistoryLimit: 3})
+	now := time.Now()
+	statuses := []domain.DiffStatus{domain.StatusSync, domain.StatusMismatch, domain.StatusSync, domain.StatusMismatch, domain.StatusSync}
+	for i, status := range statuses {
+		s.Set(checkedAt("users-a", status, now.Add(time.Duration(i-len(statuses))*time.Minute)))
+	}
+
+	events := s.History(domain.HistoryQuery{})
+	if len(events) != 3 {
+		t.Fatalf("kept %d events, want the latest 3", len(events))
+	}
+	if !events[0].Timestamp.Equal(now.Add(-time.Minute)) || events[0].To != domain.StatusSync {
+		t.Errorf("newest event = %+v, want the last recovery", events[0])
+	}
+
+	if limited := s.History(domain.HistoryQuery{Limit: 2}); len(limited) != 2 || !limited[0].Timestamp.Equal(events[0].Timestamp) {
+		t.Errorf("History(Limit: 2) = %+v, want the newest 2 events", limited)
+	}
+}
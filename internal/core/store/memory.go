@@ -0,0 +1,234 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// maxImageRecords bounds the image history kept per service
+const maxImageRecords = 20
+
+// Memory is the default Store, keeping results in memory only
+type Memory struct {
+	mu      sync.RWMutex
+	results map[string]*domain.ScanResult    // key: domain.ResultKey.String()
+	images  map[string][]*domain.ImageRecord // key: clusterName/serviceName, newest first
+	history map[string][]domain.HistoryEvent // key: clusterName/serviceName, oldest first
+	// snapshots holds schema snapshots referenced by results, key: snapshot ID
+	snapshots map[string]*snapshot
+
+	historyRetention time.Duration
+	historyLimit     int
+
+	// watchers receive result changes
+	watchers watchers
+}
+
+// NewMemory creates an empty in-memory store
+func NewMemory(opts Options) *Memory {
+	opts = opts.withDefaults()
+	return &Memory{
+		results:          make(map[string]*domain.ScanResult),
+		images:           make(map[string][]*domain.ImageRecord),
+		history:          make(map[string][]domain.HistoryEvent),
+		snapshots:        make(map[string]*snapshot),
+		historyRetention: opts.HistoryRetention,
+		historyLimit:     opts.HistoryLimit,
+	}
+}
+
+// Set stores or updates a scan result for a pod
+func (s *Memory) Set(result *domain.ScanResult) {
+	s.set(result)
+}
+
+// set stores a result and reports whether a history event was recorded
+func (s *Memory) set(result *domain.ScanResult) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := result.Key().String()
+	previous := s.results[key]
+	s.results[key] = result
+	s.recordImage(result)
+
+	// Storing the same validation again, e.g. when mirroring a peer, is not a change
+	if previous == nil || !previous.LastChecked.Equal(result.LastChecked) || previous.Status != result.Status {
+		s.watchers.notify(Change{Type: ChangeSet, Result: result})
+	}
+	return s.recordHistory(previous, result)
+}
+
+// recordImage updates the image-to-schema history of the result's service.
+// Results without an image digest or a live schema are not recorded.
+// Callers must hold the write lock.
+func (s *Memory) recordImage(result *domain.ScanResult) {
+	if result.ImageDigest == "" || result.LiveFingerprint == "" {
+		return
+	}
+
+	key := result.ClusterName + "/" + result.ServiceName
+	records := s.images[key]
+	for i, record := range records {
+		if record.ImageDigest == result.ImageDigest {
+			record.LiveFingerprint = result.LiveFingerprint
+			record.BSRCommit = result.BSRCommit
+			record.Status = result.Status
+			record.LastSeen = result.LastChecked
+			// Move the most recently seen image to the front
+			copy(records[1:i+1], records[:i])
+			records[0] = record
+			return
+		}
+	}
+
+	record := &domain.ImageRecord{
+		ClusterName:     result.ClusterName,
+		ServiceName:     result.ServiceName,
+		Image:           result.Image,
+		ImageDigest:     result.ImageDigest,
+		LiveFingerprint: result.LiveFingerprint,
+		BSRCommit:       result.BSRCommit,
+		Status:          result.Status,
+		FirstSeen:       result.LastChecked,
+		LastSeen:        result.LastChecked,
+	}
+	records = append([]*domain.ImageRecord{record}, records...)
+	if len(records) > maxImageRecords {
+		records = records[:maxImageRecords]
+	}
+	s.images[key] = records
+}
+
+// GetImageHistory returns the image-to-schema history of every service,
+// most recently seen image first
+func (s *Memory) GetImageHistory() []domain.ImageRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.images))
+	for key := range s.images {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var history []domain.ImageRecord
+	for _, key := range keys {
+		for _, record := range s.images[key] {
+			history = append(history, *record)
+		}
+	}
+	return history
+}
+
+// Get retrieves a scan result by its key
+func (s *Memory) Get(key domain.ResultKey) (*domain.ScanResult, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result, exists := s.results[key.String()]
+	return result, exists
+}
+
+// GetAll retrieves all scan results
+func (s *Memory) GetAll() []*domain.ScanResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]*domain.ScanResult, 0, len(s.results))
+	for _, result := range s.results {
+		results = append(results, result)
+	}
+	return results
+}
+
+// Delete removes a scan result by its key
+func (s *Memory) Delete(key domain.ResultKey) {
+	s.delete(key)
+}
+
+// delete removes a result, records the removal in the drift history and
+// returns the removed result (nil if there was none)
+func (s *Memory) delete(key domain.ResultKey) *domain.ScanResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, exists := s.results[key.String()]
+	if !exists {
+		return nil
+	}
+	delete(s.results, key.String())
+	s.recordRemoval(previous)
+	s.watchers.notify(Change{Type: ChangeDelete, Result: previous})
+	return previous
+}
+
+// GetWorkloads aggregates the stored results by owning workload
+// (Deployment, StatefulSet, DaemonSet), reporting replicas in sync vs drifted
+func (s *Memory) GetWorkloads() []domain.WorkloadSummary {
+	return domain.AggregateWorkloads(s.GetAll())
+}
+
+// Count returns the total number of stored results
+func (s *Memory) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.results)
+}
+
+// Subscribe returns a channel receiving every result change. Changes are
+// delivered in the order they were made; a subscriber that falls more than
+// subscriberBuffer changes behind has its channel closed.
+func (s *Memory) Subscribe() (<-chan Change, func()) {
+	return s.watchers.subscribe()
+}
+
+// imageRecords returns a copy of the image history of a service, newest first
+func (s *Memory) imageRecords(clusterName, serviceName string) []domain.ImageRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := s.images[clusterName+"/"+serviceName]
+	history := make([]domain.ImageRecord, 0, len(records))
+	for _, record := range records {
+		history = append(history, *record)
+	}
+	return history
+}
+
+// restore loads previously persisted results, image history, drift history and schema snapshots
+func (s *Memory) restore(results []*domain.ScanResult, images map[string][]*domain.ImageRecord, history map[string][]domain.HistoryEvent, snapshots map[string][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, result := range results {
+		s.results[result.Key().String()] = result
+	}
+	for key, records := range images {
+		s.images[key] = records
+	}
+	for key, events := range history {
+		s.history[key] = events
+	}
+	now := time.Now()
+	for id, data := range snapshots {
+		s.snapshots[id] = &snapshot{data: data, added: now}
+	}
+}
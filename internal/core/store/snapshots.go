@@ -0,0 +1,84 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import "time"
+
+// snapshotGrace is how long a schema snapshot is kept without being referenced
+// by a result, so a snapshot stored just before its result is not pruned
+const snapshotGrace = 10 * time.Minute
+
+// snapshot is a serialized FileDescriptorSet kept by the store
+type snapshot struct {
+	data  []byte
+	added time.Time
+}
+
+// PutSnapshot stores a serialized FileDescriptorSet under its ID. Snapshots
+// are stored once per ID and pruned once no result references them.
+func (s *Memory) PutSnapshot(id string, data []byte) {
+	s.putSnapshot(id, data)
+}
+
+// putSnapshot stores a snapshot unless it is already present, and returns
+// whether it was added along with the IDs of the snapshots pruned to make room
+func (s *Memory) putSnapshot(id string, data []byte) (bool, []string) {
+	if id == "" || len(data) == 0 {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.snapshots[id]; exists {
+		return false, nil
+	}
+	now := time.Now()
+	pruned := s.pruneSnapshots(now)
+	s.snapshots[id] = &snapshot{data: data, added: now}
+	return true, pruned
+}
+
+// GetSnapshot returns the serialized FileDescriptorSet stored under an ID
+func (s *Memory) GetSnapshot(id string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap, exists := s.snapshots[id]
+	if !exists {
+		return nil, false
+	}
+	return snap.data, true
+}
+
+// pruneSnapshots drops snapshots older than snapshotGrace that no stored
+// result references, and returns their IDs.
+// Callers must hold the write lock.
+func (s *Memory) pruneSnapshots(now time.Time) []string {
+	referenced := make(map[string]bool, len(s.snapshots))
+	for _, result := range s.results {
+		referenced[result.LiveSnapshot] = true
+		referenced[result.BSRSnapshot] = true
+	}
+
+	var pruned []string
+	for id, snap := range s.snapshots {
+		if !referenced[id] && now.Sub(snap.added) > snapshotGrace {
+			delete(s.snapshots, id)
+			pruned = append(pruned, id)
+		}
+	}
+	return pruned
+}
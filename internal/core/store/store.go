@@ -12,93 +12,98 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package store provides thread-safe in-memory storage for scan results.
+// Package store provides thread-safe storage for scan results.
 //
-// The store maintains a map of scan results keyed by pod identifier
-// (namespace/name). It uses a read-write mutex to ensure safe concurrent
-// access from multiple goroutines.
+// The Store interface is the storage port used by the scanner and the web
+// dashboard. Results are keyed by pod identifier (cluster/namespace/name, plus
+// the Kubernetes Service in service discovery mode, see domain.ResultKey).
+// Two implementations are available:
+//   - Memory: the default, keeps results in maps guarded by a read-write mutex
+//   - Bolt: keeps results in memory and writes them through to a BoltDB file,
+//     so the dashboard survives restarts
 //
 // The store supports:
 //   - Setting results (upsert operation)
 //   - Getting a specific result by pod identifier
 //   - Retrieving all results
+//   - Aggregating results per workload
+//   - Tracking which container images served which live schema
+//   - Recording status transitions and diff snapshots, with retention limits
+//   - Keeping the live and BSR schemas (FileDescriptorSets) referenced by results,
+//     deduplicated by content
 //   - Deleting results
 //   - Counting total stored results
+//   - Notifying subscribers of result changes
 //
 // Example usage:
 //
-//	store := store.New()
+//	store := store.NewMemory(store.Options{})
 //	store.Set(scanResult)
-//	result, exists := store.Get("default", "my-pod")
+//	result, exists := store.Get(domain.ResultKey{ClusterName: "default", Namespace: "default", PodName: "my-pod"})
 package store
 
 import (
-	"sync"
+	"time"
 
 	"github.com/uzdada/protodiff/internal/core/domain"
 )
 
-// Store provides thread-safe in-memory storage for scan results
-type Store struct {
-	mu      sync.RWMutex
-	results map[string]*domain.ScanResult // key: podNamespace/podName
-}
-
-// New creates a new Store instance
-func New() *Store {
-	return &Store{
-		results: make(map[string]*domain.ScanResult),
-	}
-}
-
-// Set stores or updates a scan result for a pod
-func (s *Store) Set(result *domain.ScanResult) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	key := s.makeKey(result.PodNamespace, result.PodName)
-	s.results[key] = result
-}
-
-// Get retrieves a scan result for a specific pod
-func (s *Store) Get(namespace, podName string) (*domain.ScanResult, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// History retention defaults
+const (
+	defaultHistoryRetention = 14 * 24 * time.Hour
+	defaultHistoryLimit     = 1000
+)
 
-	key := s.makeKey(namespace, podName)
-	result, exists := s.results[key]
-	return result, exists
+// Options configures a store
+type Options struct {
+	// HistoryRetention is how long history events are kept (default: 14 days).
+	// The latest event of each pod is kept regardless, as the baseline of its timeline.
+	HistoryRetention time.Duration
+	// HistoryLimit caps the history events kept per service (default: 1000)
+	HistoryLimit int
 }
 
-// GetAll retrieves all scan results
-func (s *Store) GetAll() []*domain.ScanResult {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	results := make([]*domain.ScanResult, 0, len(s.results))
-	for _, result := range s.results {
-		results = append(results, result)
+// withDefaults fills in unset options
+func (o Options) withDefaults() Options {
+	if o.HistoryRetention <= 0 {
+		o.HistoryRetention = defaultHistoryRetention
 	}
-	return results
-}
-
-// Delete removes a scan result for a specific pod
-func (s *Store) Delete(namespace, podName string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	key := s.makeKey(namespace, podName)
-	delete(s.results, key)
+	if o.HistoryLimit <= 0 {
+		o.HistoryLimit = defaultHistoryLimit
+	}
+	return o
 }
 
-// Count returns the total number of stored results
-func (s *Store) Count() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return len(s.results)
+// Store stores scan results and the history derived from them
+type Store interface {
+	// Set stores or updates a scan result for a pod
+	Set(result *domain.ScanResult)
+	// Get retrieves a scan result by its key
+	Get(key domain.ResultKey) (*domain.ScanResult, bool)
+	// GetAll retrieves all scan results
+	GetAll() []*domain.ScanResult
+	// Delete removes a scan result by its key
+	Delete(key domain.ResultKey)
+	// GetWorkloads aggregates the stored results by owning workload
+	GetWorkloads() []domain.WorkloadSummary
+	// GetImageHistory returns the image-to-schema history of every service
+	GetImageHistory() []domain.ImageRecord
+	// History returns the recorded status transitions matching the query, newest first
+	History(query domain.HistoryQuery) []domain.HistoryEvent
+	// PutSnapshot stores a serialized FileDescriptorSet under its ID (see domain.SnapshotID)
+	PutSnapshot(id string, data []byte)
+	// GetSnapshot returns the serialized FileDescriptorSet stored under an ID
+	GetSnapshot(id string) ([]byte, bool)
+	// Count returns the total number of stored results
+	Count() int
+	// Subscribe returns a channel receiving every result change and a function
+	// ending the subscription. The channel is closed when the subscription ends,
+	// or early when the subscriber falls too far behind.
+	Subscribe() (<-chan Change, func())
 }
 
-// makeKey creates a composite key from namespace and pod name
-func (s *Store) makeKey(namespace, podName string) string {
-	return namespace + "/" + podName
-}
+// Compile-time interface checks
+var (
+	_ Store = (*Memory)(nil)
+	_ Store = (*Bolt)(nil)
+)
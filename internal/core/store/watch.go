@@ -0,0 +1,89 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"sync"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// subscriberBuffer bounds the changes queued for a subscriber
+const subscriberBuffer = 256
+
+// ChangeType tells how a stored result changed
+type ChangeType string
+
+const (
+	// ChangeSet means a result was stored or updated
+	ChangeSet ChangeType = "set"
+	// ChangeDelete means a result was removed
+	ChangeDelete ChangeType = "delete"
+)
+
+// Change describes an update of a stored result
+type Change struct {
+	Type ChangeType
+	// Result is the stored result, or the removed one for ChangeDelete
+	Result *domain.ScanResult
+}
+
+// watchers fans out result changes to subscribers
+type watchers struct {
+	mu   sync.Mutex
+	subs map[chan Change]struct{}
+}
+
+// subscribe registers a subscriber and returns its channel and a function
+// ending the subscription
+func (w *watchers) subscribe() (<-chan Change, func()) {
+	ch := make(chan Change, subscriberBuffer)
+
+	w.mu.Lock()
+	if w.subs == nil {
+		w.subs = make(map[chan Change]struct{})
+	}
+	w.subs[ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() { w.remove(ch) }
+}
+
+// remove ends a subscription and closes its channel, unless already done
+func (w *watchers) remove(ch chan Change) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subs[ch]; ok {
+		delete(w.subs, ch)
+		close(ch)
+	}
+}
+
+// notify delivers a change to every subscriber without blocking. Subscribers
+// whose buffer is full are dropped, so they can tell that they missed changes.
+func (w *watchers) notify(change Change) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs {
+		select {
+		case ch <- change:
+		default:
+			delete(w.subs, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// nextChange reads a change, failing the test if none is pending
+func nextChange(t *testing.T, changes <-chan Change) Change {
+	t.Helper()
+	select {
+	case change, ok := <-changes:
+		if !ok {
+			t.Fatal("subscription closed, want a change")
+		}
+		return change
+	default:
+		t.Fatal("no change pending")
+		return Change{}
+	}
+}
+
+func TestMemorySubscribe(t *testing.T) {
+	s := NewMemory(Options{})
+	changes, unsubscribe := s.Subscribe()
+
+	synced := testResult("users-a", domain.StatusSync)
+	s.Set(synced)
+	// Storing the same validation again, e.g. when mirroring a peer, is not a change
+	s.Set(synced)
+	drifted := testResult("users-a", domain.StatusMismatch)
+	s.Set(drifted)
+	s.Delete(drifted.Key())
+	// Deleting a missing result is not a change either
+	s.Delete(drifted.Key())
+
+	want := []Change{
+		{Type: ChangeSet, Result: synced},
+		{Type: ChangeSet, Result: drifted},
+		{Type: ChangeDelete, Result: drifted},
+	}
+	for i, w := range want {
+		if got := nextChange(t, changes); got != w {
+			t.Errorf("change %d = %s %s, want %s %s", i, got.Type, got.Result.Status, w.Type, w.Result.Status)
+		}
+	}
+	select {
+	case change := <-changes:
+		t.Errorf("got extra change %+v", change)
+	default:
+	}
+
+	unsubscribe()
+	if _, ok := <-changes; ok {
+		t.Error("channel still open after unsubscribing")
+	}
+	// Ending a subscription twice is harmless, and later changes are not delivered
+	unsubscribe()
+	s.Set(synced)
+}
+
+func TestMemorySubscribeDropsSlowSubscriber(t *testing.T) {
+	s := NewMemory(Options{})
+	slow, unsubscribeSlow := s.Subscribe()
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := s.Subscribe()
+	defer unsubscribeFast()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		s.Set(testResult(fmt.Sprintf("users-%d", i), domain.StatusSync))
+		nextChange(t, fast)
+	}
+
+	// The slow subscriber gets the changes it had room for, then a closed channel
+	for i := 0; i < subscriberBuffer; i++ {
+		nextChange(t, slow)
+	}
+	select {
+	case change, ok := <-slow:
+		if ok {
+			t.Fatalf("got change %+v past the buffer, want the channel closed", change)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("slow subscriber's channel was not closed")
+	}
+
+	// Resubscribing after a resync delivers new changes again
+	resynced, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+	drifted := testResult("users-0", domain.StatusMismatch)
+	s.Set(drifted)
+	if got := nextChange(t, resynced); got.Result != drifted {
+		t.Errorf("change after resubscribing = %+v, want the drift of users-0", got)
+	}
+	if got := nextChange(t, fast); got.Result != drifted {
+		t.Errorf("fast subscriber got %+v, want the drift of users-0", got)
+	}
+}
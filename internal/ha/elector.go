@@ -0,0 +1,210 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ha lets several ProtoDiff replicas run side by side.
+//
+// With leader election, replicas compete for a coordination.k8s.io Lease and
+// only the leader scans. Followers mirror the leader's results by fetching
+// them from its web server, so every replica serves a complete dashboard and
+// BSR is only queried once per scan cycle.
+//
+// With sharding, every replica scans: a consistent hash ring over the live
+// members of a shard group (one Lease per member) assigns each pod or service
+// to one replica, and members merge each other's results.
+//
+// Replica identities carry the advertised web address ("name@host:port") so
+// followers can locate the leader from the Lease holder identity alone.
+//
+// Example usage:
+//
+//	elector := ha.NewElector(k8sClient, ha.ElectorOptions{
+//	    Namespace: "protodiff-system",
+//	    Name:      "protodiff",
+//	    Identity:  ha.Identity(podName, "10.0.0.12:8080"),
+//	})
+//	go elector.Run(ctx, scanner.Start)
+//	go ha.NewReplicator(elector, dataStore).Run(ctx)
+package ha
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Lease timings, matching the defaults of Kubernetes controllers
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// leaseTiming holds the Lease durations used by the elector
+type leaseTiming struct {
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// ElectorOptions configures leader election
+type ElectorOptions struct {
+	// Namespace and Name locate the Lease
+	Namespace string
+	Name      string
+	// Identity uniquely identifies this replica, see Identity
+	Identity string
+}
+
+// Elector runs Lease-based leader election and tracks the current leader
+type Elector struct {
+	leases coordinationv1.LeasesGetter
+	opts   ElectorOptions
+	timing leaseTiming
+
+	mu       sync.RWMutex
+	leader   string
+	isLeader bool
+
+	// leadMu serializes calls to the lead function across terms
+	leadMu sync.Mutex
+}
+
+// NewElector creates an elector using the given Lease client
+func NewElector(leases coordinationv1.LeasesGetter, opts ElectorOptions) *Elector {
+	return &Elector{
+		leases: leases,
+		opts:   opts,
+		timing: leaseTiming{
+			leaseDuration: leaseDuration,
+			renewDeadline: renewDeadline,
+			retryPeriod:   retryPeriod,
+		},
+	}
+}
+
+// Run takes part in leader election until ctx is cancelled.
+// lead is called when this replica becomes the leader, with a context that is
+// cancelled when leadership is lost. A replica that loses leadership rejoins the
+// election; should it win again, lead is not called before the previous call returned.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context) error) error {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: e.opts.Namespace,
+			Name:      e.opts.Name,
+		},
+		Client:     e.leases,
+		LockConfig: resourcelock.ResourceLockConfig{Identity: e.opts.Identity},
+	}
+
+	for {
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			Name:            e.opts.Name,
+			LeaseDuration:   e.timing.leaseDuration,
+			RenewDeadline:   e.timing.renewDeadline,
+			RetryPeriod:     e.timing.retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leaderCtx context.Context) {
+					// A previous term's work must finish before a new one starts
+					e.leadMu.Lock()
+					defer e.leadMu.Unlock()
+
+					log.Printf("Acquired leadership of lease %s/%s", e.opts.Namespace, e.opts.Name)
+					e.setLeading(true)
+					if err := lead(leaderCtx); err != nil && leaderCtx.Err() == nil {
+						log.Printf("Leader work stopped: %v", err)
+					}
+				},
+				OnStoppedLeading: func() {
+					e.setLeading(false)
+					log.Printf("Released leadership of lease %s/%s", e.opts.Namespace, e.opts.Name)
+				},
+				OnNewLeader: func(identity string) {
+					e.setLeader(identity)
+					if identity != e.opts.Identity {
+						log.Printf("New leader elected: %s", identity)
+					}
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create leader elector: %w", err)
+		}
+
+		elector.Run(ctx)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Println("Lost leadership, rejoining leader election")
+	}
+}
+
+// IsLeader reports whether this replica currently holds the Lease
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// Leader returns the identity of the current leader, or "" if none is known
+func (e *Elector) Leader() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Identity returns this replica's identity
+func (e *Elector) Identity() string {
+	return e.opts.Identity
+}
+
+func (e *Elector) setLeading(leading bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.isLeader = leading
+	if leading {
+		e.leader = e.opts.Identity
+	}
+}
+
+func (e *Elector) setLeader(identity string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = identity
+}
+
+// Identity builds a replica identity from its name and advertised web address
+func Identity(name, address string) string {
+	if address == "" {
+		return name
+	}
+	return name + "@" + address
+}
+
+// PeerAddress returns the advertised web address of a replica identity,
+// or "" when the replica doesn't advertise one
+func PeerAddress(identity string) string {
+	_, address, _ := strings.Cut(identity, "@")
+	return address
+}
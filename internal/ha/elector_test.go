@@ -0,0 +1,169 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testLeaseNamespace = "protodiff-system"
+	testLeaseName      = "protodiff"
+)
+
+// newTestElector creates an elector with short Lease timings
+func newTestElector(client kubernetes.Interface, identity string) *Elector {
+	elector := NewElector(client.CoordinationV1(), ElectorOptions{
+		Namespace: testLeaseNamespace,
+		Name:      testLeaseName,
+		Identity:  identity,
+	})
+	elector.timing = leaseTiming{
+		leaseDuration: time.Second,
+		renewDeadline: 500 * time.Millisecond,
+		retryPeriod:   100 * time.Millisecond,
+	}
+	return elector
+}
+
+// runElector runs an elector whose lead function holds leadership until it is lost.
+// The returned function stops the elector and waits for Run to return.
+func runElector(t *testing.T, elector *Elector) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// waitFor polls condition until it holds or the timeout expires
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestElectorFailoverOnRelease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	a := newTestElector(client, "a@10.0.0.1:8080")
+	b := newTestElector(client, "b@10.0.0.2:8080")
+
+	stopA := runElector(t, a)
+	defer stopA()
+	waitFor(t, "a to lead", a.IsLeader)
+
+	stopB := runElector(t, b)
+	defer stopB()
+	waitFor(t, "b to observe a as leader", func() bool { return b.Leader() == a.Identity() })
+	if b.IsLeader() {
+		t.Fatal("b leads while a holds the Lease")
+	}
+
+	// Stopping a releases the Lease, so b takes over without waiting for it to expire
+	stopA()
+	waitFor(t, "b to lead", b.IsLeader)
+	if a.IsLeader() {
+		t.Error("a still reports leadership after stopping")
+	}
+	if got := b.Leader(); got != b.Identity() {
+		t.Errorf("b.Leader() = %q, want %q", got, b.Identity())
+	}
+}
+
+func TestElectorFailoverOnExpiredLease(t *testing.T) {
+	// A leader that crashed leaves its Lease behind without releasing it
+	renewed := metav1.NewMicroTime(time.Now())
+	holder, duration := "crashed@10.0.0.1:8080", int32(1)
+	client := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testLeaseNamespace, Name: testLeaseName},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			AcquireTime:          &renewed,
+			RenewTime:            &renewed,
+		},
+	})
+	b := newTestElector(client, "b@10.0.0.2:8080")
+
+	stopB := runElector(t, b)
+	defer stopB()
+	waitFor(t, "b to observe the crashed leader", func() bool { return b.Leader() == holder || b.IsLeader() })
+	waitFor(t, "b to lead once the Lease expired", b.IsLeader)
+
+	lease, err := client.CoordinationV1().Leases(testLeaseNamespace).Get(context.Background(), testLeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get Lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != b.Identity() {
+		t.Errorf("Lease holder = %v, want %q", lease.Spec.HolderIdentity, b.Identity())
+	}
+}
+
+func TestElectorDoesNotOverlapTerms(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	elector := newTestElector(client, "a@10.0.0.1:8080")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	terms := make(chan struct{}, 10)
+	release := make(chan struct{})
+	go elector.Run(ctx, func(ctx context.Context) error {
+		terms <- struct{}{}
+		// Keep working past the end of the term
+		<-release
+		return nil
+	})
+
+	<-terms
+	// Another replica takes the Lease over, ending the term
+	lease, err := client.CoordinationV1().Leases(testLeaseNamespace).Get(ctx, testLeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get Lease: %v", err)
+	}
+	other := "b@10.0.0.2:8080"
+	lease.Spec.HolderIdentity = &other
+	if _, err := client.CoordinationV1().Leases(testLeaseNamespace).Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update Lease: %v", err)
+	}
+	waitFor(t, "leadership to be lost", func() bool { return !elector.IsLeader() })
+
+	select {
+	case <-terms:
+		t.Fatal("a new term started while the previous one was still running")
+	case <-time.After(2 * time.Second):
+	}
+	close(release)
+}
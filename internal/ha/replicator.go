@@ -0,0 +1,179 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/web"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+const (
+	// replicationInterval is how often followers fetch the leader's results
+	replicationInterval = 15 * time.Second
+	// fetchTimeout bounds a single results request to a peer
+	fetchTimeout = 10 * time.Second
+)
+
+// Replicator keeps a follower's store in sync with the leader's results
+type Replicator struct {
+	elector  *Elector
+	store    store.Store
+	client   *http.Client
+	interval time.Duration
+}
+
+// NewReplicator creates a replicator mirroring the elected leader into the store
+func NewReplicator(elector *Elector, store store.Store) *Replicator {
+	return &Replicator{
+		elector:  elector,
+		store:    store,
+		client:   &http.Client{Timeout: fetchTimeout},
+		interval: replicationInterval,
+	}
+}
+
+// Run mirrors the leader's results on every tick until ctx is cancelled.
+// Nothing is fetched while this replica is the leader.
+func (r *Replicator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.sync(ctx); err != nil {
+				log.Printf("Warning: Failed to replicate results from leader: %v", err)
+			}
+		}
+	}
+}
+
+// sync replaces the local results with the leader's
+func (r *Replicator) sync(ctx context.Context) error {
+	if r.elector.IsLeader() {
+		return nil
+	}
+	leader := r.elector.Leader()
+	if leader == "" {
+		return nil
+	}
+	address := PeerAddress(leader)
+	if address == "" {
+		return fmt.Errorf("leader %s does not advertise an address", leader)
+	}
+
+	results, err := FetchResults(ctx, r.client, address)
+	if err != nil {
+		return err
+	}
+	mirrorResults(ctx, r.client, r.store, address, results)
+	return nil
+}
+
+// FetchResults retrieves the scan results served by a peer's web server
+func FetchResults(ctx context.Context, client *http.Client, address string) ([]*domain.ScanResult, error) {
+	url := "http://" + address + web.InternalResultsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch results from %s: %w", address, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch results from %s: %s", address, resp.Status)
+	}
+
+	var results []*domain.ScanResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode results from %s: %w", address, err)
+	}
+	return results, nil
+}
+
+// FetchSnapshot retrieves a raw schema snapshot served by a peer's web server
+func FetchSnapshot(ctx context.Context, client *http.Client, address, id string) ([]byte, error) {
+	url := "http://" + address + web.InternalSnapshotsPath + "?id=" + neturl.QueryEscape(id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot %s from %s: %w", id, address, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch snapshot %s from %s: %s", id, address, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s from %s: %w", id, address, err)
+	}
+	return data, nil
+}
+
+// copySnapshots fetches the schema snapshots referenced by a peer's result
+// that the store does not have yet
+func copySnapshots(ctx context.Context, client *http.Client, s store.Store, address string, result *domain.ScanResult) {
+	for _, id := range []string{result.LiveSnapshot, result.BSRSnapshot} {
+		if id == "" {
+			continue
+		}
+		if _, ok := s.GetSnapshot(id); ok {
+			continue
+		}
+		data, err := FetchSnapshot(ctx, client, address, id)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		s.PutSnapshot(id, data)
+	}
+}
+
+// mirrorResults makes the store hold exactly the given results, copying the
+// schema snapshots they reference from the peer at address
+func mirrorResults(ctx context.Context, client *http.Client, s store.Store, address string, results []*domain.ScanResult) {
+	keep := make(map[string]bool, len(results))
+	for _, result := range results {
+		keep[result.Key().String()] = true
+		copySnapshots(ctx, client, s, address, result)
+		s.Set(result)
+	}
+	for _, result := range s.GetAll() {
+		if !keep[result.Key().String()] {
+			s.Delete(result.Key())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/web"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakePeer serves results and snapshots like a replica's web server
+type fakePeer struct {
+	mu        sync.Mutex
+	results   []*domain.ScanResult
+	snapshots map[string][]byte
+}
+
+func (p *fakePeer) setResults(results ...*domain.ScanResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results = results
+}
+
+func (p *fakePeer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch r.URL.Path {
+	case web.InternalResultsPath:
+		json.NewEncoder(w).Encode(p.results)
+	case web.InternalSnapshotsPath:
+		data, ok := p.snapshots[r.URL.Query().Get("id")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testResult(pod string, status domain.DiffStatus) *domain.ScanResult {
+	return &domain.ScanResult{
+		ClusterName:  "default",
+		PodNamespace: "prod",
+		PodName:      pod,
+		ServiceName:  "users",
+		Status:       status,
+		LastChecked:  time.Now(),
+	}
+}
+
+func TestReplicatorMirrorsLeaderAndStopsAfterFailover(t *testing.T) {
+	peer := &fakePeer{snapshots: map[string][]byte{"live-1": []byte("live schema")}}
+	server := httptest.NewServer(peer)
+	defer server.Close()
+
+	client := fake.NewSimpleClientset()
+	leader := newTestElector(client, Identity("leader", strings.TrimPrefix(server.URL, "http://")))
+	follower := newTestElector(client, Identity("follower", "10.0.0.2:8080"))
+
+	stopLeader := runElector(t, leader)
+	defer stopLeader()
+	waitFor(t, "leader to lead", leader.IsLeader)
+	stopFollower := runElector(t, follower)
+	defer stopFollower()
+	waitFor(t, "follower to observe the leader", func() bool { return follower.Leader() == leader.Identity() })
+
+	followerStore := store.NewMemory(store.Options{})
+	replicator := NewReplicator(follower, followerStore)
+	ctx := context.Background()
+
+	withSnapshot := testResult("users-a", domain.StatusSync)
+	withSnapshot.LiveSnapshot = "live-1"
+	peer.setResults(withSnapshot, testResult("users-b", domain.StatusMismatch))
+	if err := replicator.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if got := followerStore.Count(); got != 2 {
+		t.Fatalf("follower has %d results, want 2", got)
+	}
+	if data, ok := followerStore.GetSnapshot("live-1"); !ok || string(data) != "live schema" {
+		t.Errorf("snapshot live-1 = %q, %v, want it copied from the leader", data, ok)
+	}
+
+	// Results the leader no longer reports are removed
+	peer.setResults(withSnapshot)
+	if err := replicator.sync(ctx); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+	if _, ok := followerStore.Get(testResult("users-b", "").Key()); ok {
+		t.Error("result of users-b was kept after the leader dropped it")
+	}
+
+	// Once the leader is gone the follower takes over and keeps its results
+	stopLeader()
+	server.Close()
+	waitFor(t, "follower to lead", follower.IsLeader)
+	if err := replicator.sync(ctx); err != nil {
+		t.Fatalf("sync() as leader error = %v", err)
+	}
+	if got := followerStore.Count(); got != 1 {
+		t.Errorf("new leader has %d results, want 1", got)
+	}
+}
+
+func TestReplicatorReportsLeaderWithoutAddress(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	leader := newTestElector(client, "leader")
+	follower := newTestElector(client, Identity("follower", "10.0.0.2:8080"))
+
+	stopLeader := runElector(t, leader)
+	defer stopLeader()
+	waitFor(t, "leader to lead", leader.IsLeader)
+	stopFollower := runElector(t, follower)
+	defer stopFollower()
+	waitFor(t, "follower to observe the leader", func() bool { return follower.Leader() == leader.Identity() })
+
+	replicator := NewReplicator(follower, store.NewMemory(store.Options{}))
+	if err := replicator.sync(context.Background()); err == nil {
+		t.Error("sync() error = nil, want an error for a leader without an address")
+	}
+}
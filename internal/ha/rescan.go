@@ -0,0 +1,136 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/uzdada/protodiff/internal/adapters/web"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// LeaderRescanner runs on-demand scans on the elected leader. The leader
+// scans locally, and followers forward requests to the leader's API; their
+// dashboard picks up the results with the next replication.
+type LeaderRescanner struct {
+	elector *Elector
+	local   web.Rescanner
+	client  *http.Client
+}
+
+// NewLeaderRescanner creates a rescanner using local while this replica leads
+func NewLeaderRescanner(elector *Elector, local web.Rescanner) *LeaderRescanner {
+	return &LeaderRescanner{
+		elector: elector,
+		local:   local,
+		client:  &http.Client{Timeout: fetchTimeout},
+	}
+}
+
+// RequestScan queues the scan on the leader
+func (r *LeaderRescanner) RequestScan(req domain.ScanRequest) (domain.ScanJob, error) {
+	if r.elector.IsLeader() {
+		return r.local.RequestScan(req)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return domain.ScanJob{}, fmt.Errorf("failed to encode scan request: %w", err)
+	}
+
+	var job domain.ScanJob
+	if err := r.forward(http.MethodPost, "/scans", body, &job); err != nil {
+		return domain.ScanJob{}, err
+	}
+	return job, nil
+}
+
+// ScanJob returns a scan from the leader
+func (r *LeaderRescanner) ScanJob(id string) (domain.ScanJob, bool) {
+	if r.elector.IsLeader() {
+		return r.local.ScanJob(id)
+	}
+	var job domain.ScanJob
+	if err := r.forward(http.MethodGet, "/scans/"+neturl.PathEscape(id), nil, &job); err != nil {
+		log.Printf("Warning: %v", err)
+		return domain.ScanJob{}, false
+	}
+	return job, true
+}
+
+// ScanJobs returns the recent scans of the leader
+func (r *LeaderRescanner) ScanJobs() []domain.ScanJob {
+	if r.elector.IsLeader() {
+		return r.local.ScanJobs()
+	}
+	var jobs []domain.ScanJob
+	if err := r.forward(http.MethodGet, "/scans", nil, &jobs); err != nil {
+		log.Printf("Warning: %v", err)
+		return []domain.ScanJob{}
+	}
+	return jobs
+}
+
+// forward sends an API request to the leader and decodes its response into v
+func (r *LeaderRescanner) forward(method, path string, body []byte, v interface{}) error {
+	leader := r.elector.Leader()
+	if leader == "" {
+		return errors.New("no leader elected yet")
+	}
+	address := PeerAddress(leader)
+	if address == "" {
+		return fmt.Errorf("leader %s does not advertise an address", leader)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	url := "http://" + address + web.APIPrefix + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to forward scan request to %s: %w", address, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("leader %s: %s", address, apiErr.Error)
+		}
+		return fmt.Errorf("leader %s: %s", address, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode scan response from %s: %w", address, err)
+	}
+	return nil
+}
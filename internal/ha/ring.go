@@ -0,0 +1,69 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// virtualNodes is the number of points each member occupies on the ring.
+// More points spread keys more evenly across members.
+const virtualNodes = 64
+
+// Ring is a consistent hash ring assigning keys to members.
+// When a member joins or leaves, only the keys on its arcs move.
+type Ring struct {
+	points []uint32
+	owners map[uint32]string
+}
+
+// NewRing builds a ring over the given members
+func NewRing(members []string) *Ring {
+	ring := &Ring{owners: make(map[uint32]string, len(members)*virtualNodes)}
+	for _, member := range members {
+		for i := 0; i < virtualNodes; i++ {
+			point := hashKey(member + "#" + strconv.Itoa(i))
+			if _, taken := ring.owners[point]; taken {
+				continue
+			}
+			ring.owners[point] = member
+			ring.points = append(ring.points, point)
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+	return ring
+}
+
+// Owner returns the member responsible for a key, or "" for an empty ring
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	hash := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// hashKey hashes a key onto the ring
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
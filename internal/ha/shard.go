@@ -0,0 +1,266 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+	coordinationapiv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+)
+
+const (
+	// shardGroupLabel marks the membership Leases of a shard group
+	shardGroupLabel = "protodiff.io/shard-group"
+	// heartbeatInterval is how often members renew their Lease, refresh the
+	// member list and merge the results of the other members
+	heartbeatInterval = 5 * time.Second
+	// memberTTL is how long a member stays in the ring without renewing its Lease
+	memberTTL = 15 * time.Second
+)
+
+// ShardOptions configures shard group membership
+type ShardOptions struct {
+	// Namespace holds the membership Leases
+	Namespace string
+	// Group names the shard group; each member owns the Lease "<group>-<member name>"
+	Group string
+	// Name is this replica's name (the pod name)
+	Name string
+	// Identity uniquely identifies this replica, see Identity
+	Identity string
+}
+
+// Membership tracks the replicas of a shard group through one Lease per member
+// and assigns work to them with a consistent hash ring. Each member scans only
+// the keys it owns and merges the results of the other members into its store.
+type Membership struct {
+	leases coordinationv1.LeasesGetter
+	store  store.Store
+	opts   ShardOptions
+	client *http.Client
+
+	mu      sync.RWMutex
+	members []string
+	ring    *Ring
+}
+
+// NewMembership creates a shard group membership for this replica
+func NewMembership(leases coordinationv1.LeasesGetter, store store.Store, opts ShardOptions) *Membership {
+	return &Membership{
+		leases: leases,
+		store:  store,
+		opts:   opts,
+		client: &http.Client{Timeout: fetchTimeout},
+		ring:   NewRing([]string{opts.Identity}),
+	}
+}
+
+// Join registers this replica and loads the current members, so the first
+// scan already covers only this replica's share
+func (m *Membership) Join(ctx context.Context) error {
+	if err := m.heartbeat(ctx); err != nil {
+		return err
+	}
+	return m.refresh(ctx)
+}
+
+// Run keeps the membership current until ctx is cancelled, then leaves the group
+func (m *Membership) Run(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.leave()
+			return
+		case <-ticker.C:
+			if err := m.heartbeat(ctx); err != nil {
+				log.Printf("Warning: Failed to renew shard membership: %v", err)
+			}
+			if err := m.refresh(ctx); err != nil {
+				log.Printf("Warning: Failed to list shard members: %v", err)
+			}
+			m.mergePeerResults(ctx)
+		}
+	}
+}
+
+// Owns reports whether this replica is responsible for a key
+func (m *Membership) Owns(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ring.Owner(key) == m.opts.Identity
+}
+
+// Members returns the identities of the live members, sorted
+func (m *Membership) Members() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.members...)
+}
+
+// leaseName returns the name of this replica's membership Lease
+func (m *Membership) leaseName() string {
+	return m.opts.Group + "-" + m.opts.Name
+}
+
+// heartbeat creates or renews this replica's membership Lease
+func (m *Membership) heartbeat(ctx context.Context) error {
+	leases := m.leases.Leases(m.opts.Namespace)
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(memberTTL.Seconds())
+
+	lease, err := leases.Get(ctx, m.leaseName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = leases.Create(ctx, &coordinationapiv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.leaseName(),
+				Namespace: m.opts.Namespace,
+				Labels:    map[string]string{shardGroupLabel: m.opts.Group},
+			},
+			Spec: coordinationapiv1.LeaseSpec{
+				HolderIdentity:       &m.opts.Identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create membership lease: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get membership lease: %w", err)
+	}
+
+	lease.Spec.HolderIdentity = &m.opts.Identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to renew membership lease: %w", err)
+	}
+	return nil
+}
+
+// refresh lists the membership Leases and rebuilds the ring when members changed.
+// Leases that were not renewed within their duration are ignored.
+func (m *Membership) refresh(ctx context.Context) error {
+	list, err := m.leases.Leases(m.opts.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: shardGroupLabel + "=" + m.opts.Group,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	members := []string{m.opts.Identity}
+	for _, lease := range list.Items {
+		if !leaseLive(lease, now) || *lease.Spec.HolderIdentity == m.opts.Identity {
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	sort.Strings(members)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if strings.Join(members, ",") == strings.Join(m.members, ",") {
+		return nil
+	}
+	log.Printf("Shard group %s changed: %d members (%s)", m.opts.Group, len(members), strings.Join(members, ", "))
+	m.members = members
+	m.ring = NewRing(members)
+	return nil
+}
+
+// leaseLive reports whether a membership Lease has been renewed recently enough
+func leaseLive(lease coordinationapiv1.Lease, now time.Time) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiry)
+}
+
+// leave deletes this replica's membership Lease so the others rebalance right away
+func (m *Membership) leave() {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	if err := m.leases.Leases(m.opts.Namespace).Delete(ctx, m.leaseName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		log.Printf("Warning: Failed to delete membership lease: %v", err)
+	}
+}
+
+// mergePeerResults fetches the results scanned by each other member and keeps
+// the newest result per pod, so the dashboard of every member is complete.
+// Results a member scanned but no longer reports are removed, as the member
+// pruned them. Results of members that left the group are kept until the new
+// owner of their pods replaces them or the scanner prunes them as stale.
+func (m *Membership) mergePeerResults(ctx context.Context) {
+	for _, member := range m.Members() {
+		if member == m.opts.Identity {
+			continue
+		}
+		address := PeerAddress(member)
+		if address == "" {
+			continue
+		}
+
+		results, err := FetchResults(ctx, m.client, address)
+		if err != nil {
+			log.Printf("Warning: Failed to merge results of shard member %s: %v", member, err)
+			continue
+		}
+		reported := make(map[string]bool, len(results))
+		for _, result := range results {
+			if result.ScannedBy != member {
+				continue
+			}
+			reported[result.Key().String()] = true
+			mergeResult(ctx, m.client, m.store, address, result)
+		}
+		for _, result := range m.store.GetAll() {
+			if result.ScannedBy == member && !reported[result.Key().String()] {
+				m.store.Delete(result.Key())
+			}
+		}
+	}
+}
+
+// mergeResult stores a result of the peer at address, with the schema snapshots
+// it references, unless the store already has a newer one for the pod
+func mergeResult(ctx context.Context, client *http.Client, s store.Store, address string, result *domain.ScanResult) {
+	existing, ok := s.Get(result.Key())
+	if ok && !existing.LastChecked.Before(result.LastChecked) {
+		return
+	}
+	copySnapshots(ctx, client, s, address, result)
+	s.Set(result)
+}
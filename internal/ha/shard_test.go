@@ -0,0 +1,70 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMergePeerResultsRemovesResultsPeerDropped(t *testing.T) {
+	peer := &fakePeer{}
+	server := httptest.NewServer(peer)
+	defer server.Close()
+	peerIdentity := Identity("peer", strings.TrimPrefix(server.URL, "http://"))
+
+	s := store.NewMemory(store.Options{})
+	membership := NewMembership(fake.NewSimpleClientset().CoordinationV1(), s, ShardOptions{
+		Namespace: testLeaseNamespace,
+		Group:     "protodiff",
+		Name:      "self",
+		Identity:  Identity("self", "10.0.0.1:8080"),
+	})
+	membership.members = []string{membership.opts.Identity, peerIdentity}
+
+	scannedBy := func(result *domain.ScanResult, replica string) *domain.ScanResult {
+		result.ScannedBy = replica
+		return result
+	}
+	own := scannedBy(testResult("users-own", domain.StatusSync), membership.opts.Identity)
+	s.Set(own)
+
+	peer.setResults(
+		scannedBy(testResult("users-a", domain.StatusSync), peerIdentity),
+		scannedBy(testResult("users-b", domain.StatusMismatch), peerIdentity),
+	)
+	membership.mergePeerResults(context.Background())
+	if got := s.Count(); got != 3 {
+		t.Fatalf("store has %d results after the first merge, want 3", got)
+	}
+
+	// The peer pruned users-b: the merged copy goes too, this replica's own result stays
+	peer.setResults(scannedBy(testResult("users-a", domain.StatusSync), peerIdentity))
+	membership.mergePeerResults(context.Background())
+	if _, ok := s.Get(testResult("users-b", "").Key()); ok {
+		t.Error("result of users-b was kept after the peer dropped it")
+	}
+	for _, pod := range []string{"users-a", "users-own"} {
+		if _, ok := s.Get(testResult(pod, "").Key()); !ok {
+			t.Errorf("result of %s was removed", pod)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import "github.com/uzdada/protodiff/internal/core/domain"
+
+// AlertNotifier receives the status transitions of validated pods
+type AlertNotifier interface {
+	// Notify is called from the scanning goroutine and must not block
+	Notify(alert domain.Alert)
+	// Reevaluate is called at the end of each scan cycle, once results of pods
+	// that are gone have been removed, and must not block
+	Reevaluate()
+}
+
+// SetAlertNotifier reports pod status transitions to notifier.
+// It must be called before Start.
+func (s *Scanner) SetAlertNotifier(notifier AlertNotifier) {
+	s.notifier = notifier
+}
+
+// notifyTransition hands the alert for a pod's status change to the notifier, if any
+func (s *Scanner) notifyTransition(previous, current *domain.ScanResult) {
+	if s.notifier == nil {
+		return
+	}
+	if alert := domain.NewAlert(previous, current); alert != nil {
+		s.notifier.Notify(*alert)
+	}
+}
+
+// reevaluateAlerts lets the notifier, if any, re-check services after a scan cycle
+func (s *Scanner) reevaluateAlerts() {
+	if s.notifier != nil {
+		s.notifier.Reevaluate()
+	}
+}
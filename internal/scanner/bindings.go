@@ -0,0 +1,199 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxQueuedBindingChanges bounds the SchemaBinding changes waiting for the scanner.
+// Changes beyond it are picked up by the next scan cycle.
+const maxQueuedBindingChanges = 32
+
+// bindingChange is a created or updated SchemaBinding to reconcile
+type bindingChange struct {
+	cluster *k8s.Client
+	binding k8s.SchemaBinding
+}
+
+// watchBindings watches the SchemaBindings of every cluster so their status is
+// reconciled as soon as they are created or their spec changes, rather than
+// at the next scan cycle
+func (s *Scanner) watchBindings(ctx context.Context) {
+	for _, cluster := range s.clusters {
+		cluster := cluster
+		err := cluster.WatchSchemaBindings(ctx, func(binding k8s.SchemaBinding) {
+			select {
+			case s.bindingChanges <- bindingChange{cluster: cluster, binding: binding}:
+			default:
+				log.Printf("Warning: Too many SchemaBinding changes queued, %s/%s waits for the next scan", cluster.Name(), binding.Key())
+			}
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to watch SchemaBindings in cluster %s: %v", cluster.Name(), err)
+		}
+	}
+}
+
+// reconcileBindingChange validates the pods of a created or updated SchemaBinding
+// and writes its status. With sharding, the replica owning the binding validates
+// all of its pods, like an on-demand scan. It runs on the scanning goroutine.
+func (s *Scanner) reconcileBindingChange(ctx context.Context, change bindingChange) {
+	cluster, binding := change.cluster, change.binding
+	if !s.ownsBinding(cluster.Name(), binding) {
+		return
+	}
+	log.Printf("SchemaBinding %s/%s changed (generation %d), reconciling", cluster.Name(), binding.Key(), binding.Generation)
+
+	pods, err := cluster.DiscoverPodsForBinding(ctx, binding)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+
+	mappings, err := s.loadServiceMappings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load ConfigMap: %v", err)
+		mappings = domain.NewServiceMappings(nil)
+	}
+	s.bsrCommits = make(map[string]string)
+
+	var results []*domain.ScanResult
+	for _, pod := range pods {
+		if pod.Overrides.Skip {
+			continue
+		}
+		results = append(results, s.validatePod(ctx, cluster, pod, mappings, false))
+	}
+	s.reconcileBindings(ctx, cluster, []k8s.SchemaBinding{binding}, map[string][]*domain.ScanResult{binding.Key(): results})
+}
+
+// discoverBindingPods lists the SchemaBindings of a cluster and the pods they select.
+// It returns an error when the bindings can't be listed; failures of single
+// bindings are logged so a broken binding doesn't stop the rest of the scan.
+func (s *Scanner) discoverBindingPods(ctx context.Context, cluster *k8s.Client) ([]k8s.SchemaBinding, []k8s.PodInfo, error) {
+	bindings, err := cluster.ListSchemaBindings(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list SchemaBindings in cluster %s: %w", cluster.Name(), err)
+	}
+	if len(bindings) == 0 {
+		return nil, nil, nil
+	}
+
+	log.Printf("Found %d SchemaBindings in cluster %s", len(bindings), cluster.Name())
+
+	var pods []k8s.PodInfo
+	for _, binding := range bindings {
+		bound, err := cluster.DiscoverPodsForBinding(ctx, binding)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		pods = append(pods, bound...)
+	}
+	return bindings, pods, nil
+}
+
+// mergeBindingPods replaces discovered pods with their SchemaBinding counterpart
+// and appends bound pods that weren't discovered otherwise. A pod selected by
+// several bindings is validated against the first one.
+func mergeBindingPods(pods, bindingPods []k8s.PodInfo) []k8s.PodInfo {
+	if len(bindingPods) == 0 {
+		return pods
+	}
+
+	bound := make(map[string]k8s.PodInfo, len(bindingPods))
+	var order []string
+	for _, pod := range bindingPods {
+		key := pod.Namespace + "/" + pod.Name
+		if existing, ok := bound[key]; ok {
+			log.Printf("Warning: Pod %s is selected by SchemaBindings %s and %s, using %s", key, existing.Binding, pod.Binding, existing.Binding)
+			continue
+		}
+		bound[key] = pod
+		order = append(order, key)
+	}
+
+	merged := make([]k8s.PodInfo, 0, len(pods)+len(bound))
+	for _, pod := range pods {
+		key := pod.Namespace + "/" + pod.Name
+		if _, ok := bound[key]; !ok {
+			merged = append(merged, pod)
+		}
+	}
+	for _, key := range order {
+		merged = append(merged, bound[key])
+	}
+	return merged
+}
+
+// reconcileBindings writes the aggregated sync state of each SchemaBinding to its status.
+// With sharding, each binding's status is written by a single replica.
+func (s *Scanner) reconcileBindings(ctx context.Context, cluster *k8s.Client, bindings []k8s.SchemaBinding, results map[string][]*domain.ScanResult) {
+	for _, binding := range bindings {
+		if !s.ownsBinding(cluster.Name(), binding) {
+			continue
+		}
+		status, condition := bindingStatus(results[binding.Key()])
+		if err := cluster.UpdateSchemaBindingStatus(ctx, binding, status, condition); err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		log.Printf("SchemaBinding %s/%s: %s (%s)", cluster.Name(), binding.Key(), condition.Reason, condition.Message)
+	}
+}
+
+// bindingStatus aggregates the scan results of a binding's pods into its status
+// and Synced condition. Drift takes precedence over validation failures.
+func bindingStatus(results []*domain.ScanResult) (k8s.SchemaBindingStatus, metav1.Condition) {
+	status := k8s.SchemaBindingStatus{PodCount: len(results)}
+	for _, result := range results {
+		switch result.Status {
+		case domain.StatusSync:
+			status.SyncCount++
+		case domain.StatusMismatch:
+			status.MismatchCount++
+		default:
+			status.UnknownCount++
+		}
+	}
+
+	condition := metav1.Condition{Type: k8s.ConditionSynced}
+	switch {
+	case status.PodCount == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = k8s.ReasonNoMatchingPods
+		condition.Message = "No running pods match the selector"
+	case status.MismatchCount > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = k8s.ReasonDriftDetected
+		condition.Message = fmt.Sprintf("%d of %d pods have schema drift", status.MismatchCount, status.PodCount)
+	case status.UnknownCount > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = k8s.ReasonValidationFailed
+		condition.Message = fmt.Sprintf("%d of %d pods could not be validated", status.UnknownCount, status.PodCount)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = k8s.ReasonInSync
+		condition.Message = fmt.Sprintf("All %d pods match the BSR schema", status.PodCount)
+	}
+	return status, condition
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/uzdada/protodiff/internal/adapters/grpc"
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Endpoint is a gRPC server validated by address rather than discovered in a cluster
+type Endpoint struct {
+	// Address is the host:port of the server
+	Address string
+	// Module is the BSR module holding the server's canonical schema
+	Module string
+	// TLS, InsecureSkipVerify and ServerName configure the reflection connection
+	TLS                bool
+	InsecureSkipVerify bool
+	ServerName         string
+	// Strict treats missing and extra services as drift
+	Strict bool
+}
+
+// Check validates the pods selected by a request once and returns their
+// results. Unlike on-demand scans it runs on the calling goroutine and leaves
+// SchemaBinding status alone, for one-shot runs such as `protodiff check`.
+// Clusters that could not be scanned are reported in the error.
+func (s *Scanner) Check(ctx context.Context, req domain.ScanRequest) ([]*domain.ScanResult, error) {
+	mappings, err := s.loadServiceMappings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load ConfigMap: %v", err)
+		mappings = domain.NewServiceMappings(nil)
+	}
+	s.bsrCommits = make(map[string]string)
+
+	var results []*domain.ScanResult
+	var scanErrs []error
+	for _, cluster := range s.clusters {
+		if req.ClusterName != "" && cluster.Name() != req.ClusterName {
+			continue
+		}
+		pods, err := s.discoverPods(ctx, cluster, mappings)
+		if err != nil {
+			scanErrs = append(scanErrs, fmt.Errorf("cluster %s: %w", cluster.Name(), err))
+			continue
+		}
+		_, bindingPods, err := s.discoverBindingPods(ctx, cluster)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		pods = mergeBindingPods(pods, bindingPods)
+
+		for _, pod := range pods {
+			if !req.Matches(cluster.Name(), pod.Namespace, pod.ServiceName, pod.Name) || pod.Overrides.Skip {
+				continue
+			}
+			results = append(results, s.validatePod(ctx, cluster, pod, mappings, true))
+		}
+	}
+	return results, errors.Join(scanErrs...)
+}
+
+// CheckEndpoint validates the schema served at an endpoint against its BSR
+// module. The result is returned without being stored.
+func (s *Scanner) CheckEndpoint(ctx context.Context, endpoint Endpoint) *domain.ScanResult {
+	host, portText, _ := net.SplitHostPort(endpoint.Address)
+	port, _ := strconv.ParseInt(portText, 10, 32)
+	pod := k8s.PodInfo{
+		Name:        endpoint.Address,
+		ServiceName: endpoint.Address,
+		IP:          host,
+		GRPCPort:    int32(port),
+		Overrides: k8s.PodOverrides{
+			TLS:           endpoint.TLS,
+			TLSInsecure:   endpoint.InsecureSkipVerify,
+			TLSServerName: endpoint.ServerName,
+		},
+		StrictComparison: endpoint.Strict,
+	}
+	result := s.createScanResult("", pod)
+	result.BSRModule = endpoint.Module
+
+	log.Printf("Connecting to %s", endpoint.Address)
+	liveSchema, err := s.grpcClient.FetchSchema(ctx, endpoint.Address, grpc.ProbeOptions{
+		TLS:                endpoint.TLS,
+		InsecureSkipVerify: endpoint.InsecureSkipVerify,
+		ServerName:         endpoint.ServerName,
+	})
+	if err != nil {
+		result.Message = fmt.Sprintf("Failed to fetch live schema: %v", err)
+		return result
+	}
+	result.LiveFingerprint = liveSchema.Fingerprint()
+	result.LiveSnapshot = s.storeSnapshot(liveSchema)
+
+	s.compareWithBSR(ctx, pod, endpoint.Module, liveSchema, result)
+	log.Printf("Validated %s: %s", endpoint.Address, result.Status)
+	return result
+}
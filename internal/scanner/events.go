@@ -0,0 +1,97 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxEventMessageLength keeps Event messages within the API server limit
+const maxEventMessageLength = 1024
+
+// storeResult stores a pod's result and records Kubernetes Events and alerts
+// when the pod's status changed since the previous scan
+func (s *Scanner) storeResult(cluster *k8s.Client, pod k8s.PodInfo, result *domain.ScanResult) {
+	previous, _ := s.store.Get(result.Key())
+	s.store.Set(result)
+	s.markSeen(result)
+
+	if s.recordEvents {
+		recordTransition(cluster, pod, previous, result)
+	}
+	s.notifyTransition(previous, result)
+}
+
+// recordTransition records an Event on the pod and its workload for a status transition.
+// Only transitions are reported, so a pod that stays drifted produces a single Event;
+// the Event broadcaster additionally deduplicates identical Events across replicas.
+func recordTransition(cluster *k8s.Client, pod k8s.PodInfo, previous, current *domain.ScanResult) {
+	previousStatus := domain.StatusUnknown
+	if previous != nil {
+		previousStatus = previous.Status
+	}
+	if previous != nil && previousStatus == current.Status {
+		return
+	}
+
+	var eventType, reason string
+	switch current.Status {
+	case domain.StatusMismatch:
+		eventType, reason = corev1.EventTypeWarning, k8s.EventReasonDriftDetected
+	case domain.StatusSync:
+		// A pod first seen in sync is not news
+		if previous == nil {
+			return
+		}
+		eventType, reason = corev1.EventTypeNormal, k8s.EventReasonDriftResolved
+	default:
+		// Pods that were never validated (e.g. still starting) are not reported
+		if previous == nil || previousStatus == domain.StatusUnknown {
+			return
+		}
+		eventType, reason = corev1.EventTypeWarning, k8s.EventReasonValidationFailed
+	}
+
+	summary := fmt.Sprintf("%s → %s against %s: %s", previousStatus, current.Status, current.BSRModule, current.Message)
+	cluster.RecordPodEvent(pod, eventType, reason, truncateEventMessage(summary))
+
+	// The workload message leaves out the pod name so replicas of the same
+	// revision collapse into one Event with a count
+	if pod.Workload != nil {
+		revision := pod.Workload.Revision
+		if revision == "" {
+			revision = "unknown revision"
+		}
+		workloadSummary := fmt.Sprintf("Replicas of %s: %s", revision, summary)
+		cluster.RecordWorkloadEvent(pod.Namespace, pod.Workload, eventType, reason, truncateEventMessage(workloadSummary))
+	}
+}
+
+// truncateEventMessage shortens a message to the Event message size limit
+func truncateEventMessage(message string) string {
+	if len(message) <= maxEventMessageLength {
+		return message
+	}
+	cut := maxEventMessageLength - len("...")
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut] + "..."
+}
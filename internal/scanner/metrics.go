@@ -0,0 +1,118 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/bsr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Failure reasons reported to the MetricsRecorder
+const (
+	reasonPortForward  = "port_forward"
+	reasonTimeout      = "timeout"
+	reasonUnavailable  = "unavailable"
+	reasonNoReflection = "reflection_unsupported"
+	reasonUnauthorized = "unauthorized"
+	reasonNotFound     = "not_found"
+	reasonRateLimited  = "rate_limited"
+	reasonServerError  = "server_error"
+	reasonOther        = "other"
+)
+
+// MetricsRecorder receives timings and failures of the scanner's work
+type MetricsRecorder interface {
+	// ObserveScan records a finished scan cycle; err is nil if every cluster was scanned
+	ObserveScan(duration time.Duration, err error)
+	// ObserveProbe records a live schema fetch; reason is "" on success
+	ObserveProbe(duration time.Duration, reason string)
+	// ObserveBSRFetch records a BSR schema fetch; reason is "" on success
+	ObserveBSRFetch(duration time.Duration, reason string)
+}
+
+// SetMetricsRecorder reports scan cycles, probes and BSR fetches to recorder.
+// It must be called before Start.
+func (s *Scanner) SetMetricsRecorder(recorder MetricsRecorder) {
+	s.metrics = recorder
+}
+
+// observeScan reports a scan cycle started at start, if a recorder is set
+func (s *Scanner) observeScan(start time.Time, err error) {
+	if s.metrics != nil {
+		s.metrics.ObserveScan(time.Since(start), err)
+	}
+}
+
+// observeProbe reports a live schema fetch started at start, if a recorder is set
+func (s *Scanner) observeProbe(start time.Time, reason string) {
+	if s.metrics != nil {
+		s.metrics.ObserveProbe(time.Since(start), reason)
+	}
+}
+
+// observeBSRFetch reports a BSR schema fetch started at start, if a recorder is set
+func (s *Scanner) observeBSRFetch(start time.Time, reason string) {
+	if s.metrics != nil {
+		s.metrics.ObserveBSRFetch(time.Since(start), reason)
+	}
+}
+
+// probeFailureReason classifies an error of a gRPC reflection request
+func probeFailureReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return reasonTimeout
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return reasonTimeout
+	case codes.Unavailable:
+		return reasonUnavailable
+	case codes.Unimplemented:
+		return reasonNoReflection
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return reasonUnauthorized
+	default:
+		return reasonOther
+	}
+}
+
+// bsrFailureReason classifies an error of a BSR schema fetch
+func bsrFailureReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return reasonTimeout
+	}
+	var statusErr *bsr.StatusError
+	if !errors.As(err, &statusErr) {
+		return reasonOther
+	}
+	switch code := statusErr.StatusCode; {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return reasonUnauthorized
+	case code == http.StatusNotFound:
+		return reasonNotFound
+	case code == http.StatusTooManyRequests:
+		return reasonRateLimited
+	case code >= http.StatusInternalServerError:
+		return reasonServerError
+	default:
+		return reasonOther
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/adapters/bsr"
+)
+
+func TestBSRFailureReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("buf export failed: %w", context.DeadlineExceeded), reasonTimeout},
+		{fmt.Errorf("buf export failed: %w", &bsr.StatusError{StatusCode: http.StatusUnauthorized}), reasonUnauthorized},
+		{fmt.Errorf("buf export failed: %w", &bsr.StatusError{StatusCode: http.StatusForbidden}), reasonUnauthorized},
+		{fmt.Errorf("buf export failed: %w", &bsr.StatusError{StatusCode: http.StatusNotFound}), reasonNotFound},
+		{fmt.Errorf("buf export failed: %w", &bsr.StatusError{StatusCode: http.StatusTooManyRequests}), reasonRateLimited},
+		{fmt.Errorf("buf export failed: %w", &bsr.StatusError{StatusCode: http.StatusServiceUnavailable}), reasonServerError},
+		{errors.New("failed to parse proto files"), reasonOther},
+	}
+	for _, tt := range tests {
+		if got := bsrFailureReason(tt.err); got != tt.want {
+			t.Errorf("bsrFailureReason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// ProgressReporter receives the progress of scan cycles
+type ProgressReporter interface {
+	// ReportProgress is called from the scanning goroutine and must not block
+	ReportProgress(progress domain.ScanProgress)
+}
+
+// SetProgressReporter reports the progress of every scan cycle to reporter.
+// It must be called before Start.
+func (s *Scanner) SetProgressReporter(reporter ProgressReporter) {
+	s.reporter = reporter
+}
+
+// beginProgress starts tracking a scan cycle
+func (s *Scanner) beginProgress() {
+	s.progress = domain.ScanProgress{
+		Running:      true,
+		ClusterCount: len(s.clusters),
+		StartedAt:    time.Now(),
+	}
+	s.reportProgress()
+}
+
+// clusterDiscovered adds the pods found in a cluster to the cycle
+func (s *Scanner) clusterDiscovered(clusterName string, pods int) {
+	s.progress.Cluster = clusterName
+	s.progress.PodCount += pods
+	s.reportProgress()
+}
+
+// podScanned counts a validated or skipped pod
+func (s *Scanner) podScanned() {
+	s.progress.PodsScanned++
+	s.reportProgress()
+}
+
+// clusterDone counts a scanned cluster, whether or not its scan succeeded
+func (s *Scanner) clusterDone() {
+	s.progress.ClustersDone++
+}
+
+// endProgress finishes the cycle
+func (s *Scanner) endProgress() {
+	finished := time.Now()
+	s.progress.Running = false
+	s.progress.Cluster = ""
+	s.progress.FinishedAt = &finished
+	s.reportProgress()
+}
+
+// reportProgress hands the current progress to the reporter, if any
+func (s *Scanner) reportProgress() {
+	if s.reporter != nil {
+		s.reporter.ReportProgress(s.progress)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"log"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// resultGraceCycles is how many scan intervals a result may go without being
+// refreshed before it is removed, whoever scanned it
+const resultGraceCycles = 3
+
+// markSeen records that a result was refreshed during the current scan cycle
+func (s *Scanner) markSeen(result *domain.ScanResult) {
+	if s.seen != nil {
+		s.seen[result.Key().String()] = true
+	}
+}
+
+// pruneResults removes the results of a successfully scanned cluster that the
+// scan cycle did not refresh, such as those of deleted pods. With sharding,
+// only results of keys this replica owns are removed right away, since the
+// other members refresh theirs. Any result not refreshed for resultGraceCycles
+// scan intervals is removed as well, e.g. one left behind by a member that left
+// the shard group or restored from disk for a pod that is gone.
+// Nothing is pruned when the cycle doesn't track results (see runScan).
+func (s *Scanner) pruneResults(clusterName string) {
+	if s.seen == nil {
+		return
+	}
+	staleBefore := time.Now().Add(-resultGraceCycles * s.scanInterval)
+	for _, result := range s.store.GetAll() {
+		if result.ClusterName != clusterName || s.seen[result.Key().String()] {
+			continue
+		}
+		if !s.ownsResult(clusterName, result) && !result.LastChecked.Before(staleBefore) {
+			continue
+		}
+		log.Printf("Removing result of %s, not seen during the scan", result.Key())
+		s.store.Delete(result.Key())
+	}
+}
+
+// ownsResult reports whether this replica is responsible for a stored result
+func (s *Scanner) ownsResult(clusterName string, result *domain.ScanResult) bool {
+	if s.shard == nil {
+		return true
+	}
+	return s.shard.Owns(s.shardKey(clusterName, k8s.PodInfo{
+		Namespace:   result.PodNamespace,
+		Name:        result.PodName,
+		ServiceName: result.ServiceName,
+	}))
+}
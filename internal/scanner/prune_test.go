@@ -0,0 +1,87 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/core/domain"
+	"github.com/uzdada/protodiff/internal/core/store"
+)
+
+// fakeShard owns the keys it lists
+type fakeShard map[string]bool
+
+func (f fakeShard) Owns(key string) bool {
+	return f[key]
+}
+
+func TestPruneResults(t *testing.T) {
+	now := time.Now()
+	result := func(cluster, pod string, age time.Duration) *domain.ScanResult {
+		return &domain.ScanResult{
+			ClusterName:  cluster,
+			PodNamespace: "prod",
+			PodName:      pod,
+			ServiceName:  "users",
+			Status:       domain.StatusSync,
+			LastChecked:  now.Add(-age),
+		}
+	}
+
+	s := &Scanner{
+		store:        store.NewMemory(store.Options{}),
+		scanInterval: time.Minute,
+		shard:        fakeShard{"home/prod/seen": true, "home/prod/gone": true},
+		seen:         make(map[string]bool),
+	}
+	for _, r := range []*domain.ScanResult{
+		result("home", "seen", 0),
+		result("home", "gone", time.Minute),
+		result("home", "peer", time.Minute),
+		result("home", "departed", 5*time.Minute),
+		result("remote", "gone", time.Hour),
+	} {
+		s.store.Set(r)
+	}
+	s.markSeen(result("home", "seen", 0))
+
+	s.pruneResults("home")
+
+	tests := []struct {
+		cluster, pod string
+		kept         bool
+	}{
+		{"home", "seen", true},      // refreshed during the cycle
+		{"home", "gone", false},     // owned and not refreshed
+		{"home", "peer", true},      // refreshed recently by the member owning it
+		{"home", "departed", false}, // not refreshed for more than three intervals
+		{"remote", "gone", true},    // another cluster
+	}
+	for _, tt := range tests {
+		key := result(tt.cluster, tt.pod, 0).Key()
+		if _, ok := s.store.Get(key); ok != tt.kept {
+			t.Errorf("result %s kept = %v, want %v", key, ok, tt.kept)
+		}
+	}
+
+	// Cycles that don't track results prune nothing
+	s.seen = nil
+	s.pruneResults("remote")
+	if got := s.store.Count(); got != 3 {
+		t.Errorf("store has %d results after an untracked cycle, want 3", got)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+const (
+	// maxQueuedScans bounds the on-demand scans waiting for the scanner
+	maxQueuedScans = 10
+	// maxScanJobs bounds the on-demand scans kept for status queries
+	maxScanJobs = 50
+)
+
+// ErrScanQueueFull is returned when too many on-demand scans are waiting
+var ErrScanQueueFull = errors.New("too many scans queued, try again later")
+
+// RequestScan queues an on-demand scan of the pods selected by the request.
+// The scan runs on the scanning goroutine between regular cycles, so it never
+// overlaps them. An identical request that is still queued is returned instead
+// of queueing a duplicate.
+func (s *Scanner) RequestScan(req domain.ScanRequest) (domain.ScanJob, error) {
+	if req.ClusterName != "" && s.cluster(req.ClusterName) == nil {
+		return domain.ScanJob{}, fmt.Errorf("unknown cluster %q", req.ClusterName)
+	}
+
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.State == domain.ScanQueued && job.Request == req {
+			return *job, nil
+		}
+	}
+
+	s.nextJobID++
+	job := &domain.ScanJob{
+		ID:       strconv.Itoa(s.nextJobID),
+		Request:  req,
+		State:    domain.ScanQueued,
+		QueuedAt: time.Now(),
+	}
+	select {
+	case s.requests <- job.ID:
+	default:
+		return domain.ScanJob{}, ErrScanQueueFull
+	}
+
+	s.jobs = append(s.jobs, job)
+	s.trimJobs()
+	log.Printf("Queued on-demand scan %s of %s", job.ID, describeScanRequest(req))
+	return *job, nil
+}
+
+// ScanJob returns the on-demand scan with the given ID
+func (s *Scanner) ScanJob(id string) (domain.ScanJob, bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.ID == id {
+			return *job, true
+		}
+	}
+	return domain.ScanJob{}, false
+}
+
+// ScanJobs returns the recent on-demand scans, newest first
+func (s *Scanner) ScanJobs() []domain.ScanJob {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	jobs := make([]domain.ScanJob, 0, len(s.jobs))
+	for i := len(s.jobs) - 1; i >= 0; i-- {
+		jobs = append(jobs, *s.jobs[i])
+	}
+	return jobs
+}
+
+// trimJobs drops the oldest finished jobs beyond maxScanJobs.
+// Callers must hold jobsMu.
+func (s *Scanner) trimJobs() {
+	excess := len(s.jobs) - maxScanJobs
+	if excess <= 0 {
+		return
+	}
+	kept := s.jobs[:0]
+	for _, job := range s.jobs {
+		if excess > 0 && job.Done() {
+			excess--
+			continue
+		}
+		kept = append(kept, job)
+	}
+	s.jobs = kept
+}
+
+// updateJob applies a change to a job under the lock
+func (s *Scanner) updateJob(id string, update func(job *domain.ScanJob)) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	for _, job := range s.jobs {
+		if job.ID == id {
+			update(job)
+			return
+		}
+	}
+}
+
+// runScanJob validates the pods selected by a queued scan, bypassing the
+// validation cache. It runs on the scanning goroutine.
+func (s *Scanner) runScanJob(ctx context.Context, id string) {
+	job, ok := s.ScanJob(id)
+	if !ok {
+		return
+	}
+	started := time.Now()
+	s.updateJob(id, func(job *domain.ScanJob) {
+		job.State = domain.ScanRunning
+		job.StartedAt = &started
+	})
+	log.Printf("Starting on-demand scan %s of %s", id, describeScanRequest(job.Request))
+
+	mappings, err := s.loadServiceMappings(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to load ConfigMap: %v", err)
+		mappings = domain.NewServiceMappings(nil)
+	}
+	s.bsrCommits = make(map[string]string)
+
+	var scanned int
+	var scanErrs []error
+	for _, cluster := range s.clusters {
+		if job.Request.ClusterName != "" && cluster.Name() != job.Request.ClusterName {
+			continue
+		}
+		scanned++
+		err := s.rescanCluster(ctx, cluster, mappings, job.Request, func(result *domain.ScanResult) {
+			s.updateJob(id, func(job *domain.ScanJob) {
+				job.PodCount++
+				switch result.Status {
+				case domain.StatusSync:
+					job.SyncCount++
+				case domain.StatusMismatch:
+					job.MismatchCount++
+				default:
+					job.UnknownCount++
+				}
+			})
+		})
+		if err != nil {
+			log.Printf("On-demand scan %s of cluster %s failed: %v", id, cluster.Name(), err)
+			scanErrs = append(scanErrs, fmt.Errorf("cluster %s: %w", cluster.Name(), err))
+		}
+	}
+
+	finished := time.Now()
+	s.updateJob(id, func(job *domain.ScanJob) {
+		job.State = domain.ScanFinished
+		if len(scanErrs) > 0 {
+			job.Error = errors.Join(scanErrs...).Error()
+			if len(scanErrs) == scanned {
+				job.State = domain.ScanFailed
+			}
+		}
+		job.FinishedAt = &finished
+	})
+	job, _ = s.ScanJob(id)
+	log.Printf("On-demand scan %s %s: %d pods validated", id, job.State, job.PodCount)
+}
+
+// rescanCluster validates the pods of a cluster selected by a request and
+// refreshes the status of the cluster's SchemaBindings. With sharding, the
+// selected pods are scanned regardless of which replica owns them.
+func (s *Scanner) rescanCluster(ctx context.Context, cluster *k8s.Client, mappings domain.ServiceMappings, req domain.ScanRequest, onResult func(*domain.ScanResult)) error {
+	pods, err := s.discoverPods(ctx, cluster, mappings)
+	if err != nil {
+		return err
+	}
+	bindings, bindingPods, err := s.discoverBindingPods(ctx, cluster)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	pods = mergeBindingPods(pods, bindingPods)
+
+	for _, pod := range pods {
+		if !req.Matches(cluster.Name(), pod.Namespace, pod.ServiceName, pod.Name) || pod.Overrides.Skip {
+			continue
+		}
+		onResult(s.validatePod(ctx, cluster, pod, mappings, true))
+	}
+
+	s.reconcileBindings(ctx, cluster, bindings, s.storedBindingResults(cluster.Name()))
+	return nil
+}
+
+// cluster returns the client of a cluster by name, or nil
+func (s *Scanner) cluster(name string) *k8s.Client {
+	for _, cluster := range s.clusters {
+		if cluster.Name() == name {
+			return cluster
+		}
+	}
+	return nil
+}
+
+// describeScanRequest renders a request for logs, e.g. "service user-service in namespace prod"
+func describeScanRequest(req domain.ScanRequest) string {
+	var parts []string
+	if req.PodName != "" {
+		parts = append(parts, "pod "+req.PodName)
+	}
+	if req.ServiceName != "" {
+		parts = append(parts, "service "+req.ServiceName)
+	}
+	if req.Namespace != "" {
+		parts = append(parts, "namespace "+req.Namespace)
+	}
+	if req.ClusterName != "" {
+		parts = append(parts, "cluster "+req.ClusterName)
+	}
+	if len(parts) == 0 {
+		return "all clusters"
+	}
+	return strings.Join(parts, " in ")
+}
@@ -19,22 +19,30 @@
 // them to detect drift. Results are stored in-memory and surfaced through the web UI.
 //
 // The main workflow is:
-//  1. Load service-to-BSR mappings from ConfigMap
-//  2. Discover pods for services specified in ConfigMap (or fallback to label-based discovery)
+//  1. Load service-to-BSR mappings from ConfigMap in the home cluster
+//  2. For each configured cluster, discover pods for services specified in ConfigMap (or fallback to label-based discovery)
+//     and pods selected by SchemaBinding resources
 //  3. For each pod:
+//     - Apply protodiff.io annotation overrides (module, port, TLS, ignored services, skip)
+//     - Reuse the outcome if the image digest was already validated against the same BSR commit
 //     - Fetch live schema via gRPC reflection
 //     - Fetch truth schema from BSR
 //     - Compare schemas and detect drift
 //  4. Store results for dashboard display
+//     and record Kubernetes Events on pods whose status changed
+//  5. Remove results of pods that are gone
+//  6. Write the aggregated sync state to each SchemaBinding's status
 //
 // The scanner runs continuously on a configurable interval (default: 30 minutes).
 package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/uzdada/protodiff/internal/adapters/bsr"
@@ -43,44 +51,97 @@ import (
 	"github.com/uzdada/protodiff/internal/config"
 	"github.com/uzdada/protodiff/internal/core/domain"
 	"github.com/uzdada/protodiff/internal/core/store"
+	"github.com/uzdada/protodiff/internal/ha"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// probeTimeout bounds each reflection probe of a candidate port
+const probeTimeout = 5 * time.Second
+
 // Scanner orchestrates the schema validation workflow
 type Scanner struct {
-	k8sClient     *k8s.Client
+	clusters      []*k8s.Client
 	grpcClient    *grpc.ReflectionClient
 	bsrClient     bsr.Client
-	store         *store.Store
+	store         store.Store
 	configMapNS   string
 	configMapName string
 	bsrTemplate   string
 	scanInterval  time.Duration
+	portForward   bool
+	discoveryMode string
+	recordEvents  bool
+
+	// replica identifies this ProtoDiff replica on its results
+	replica string
+	// shard restricts scanning to this replica's share (nil scans everything)
+	shard   Shard
+	shardBy string
+
+	// validated caches outcomes per image digest and BSR commit
+	validated *validationCache
+	// bsrCommits caches module-to-commit resolution for the current scan cycle
+	bsrCommits map[string]string
+	// seen holds the keys of results refreshed during the current scan cycle
+	// (nil outside of scan cycles)
+	seen map[string]bool
+
+	// progress tracks the current scan cycle for the reporter
+	progress domain.ScanProgress
+	reporter ProgressReporter
+	// metrics receives scan, probe and BSR fetch timings (nil disables them)
+	metrics MetricsRecorder
+	// notifier receives pod status transitions for alerting (nil disables alerts)
+	notifier AlertNotifier
+
+	// requests carries the IDs of queued on-demand scans to the scanning goroutine
+	requests chan string
+	// bindingChanges carries created or updated SchemaBindings to the scanning goroutine
+	bindingChanges chan bindingChange
+	// jobs tracks recent on-demand scans, oldest first
+	jobsMu    sync.Mutex
+	jobs      []*domain.ScanJob
+	nextJobID int
 }
 
-// NewScanner creates a new scanner instance
+// NewScanner creates a new scanner instance.
+// The first cluster is the home cluster holding the mapping ConfigMap;
+// pods are discovered and validated in every cluster.
 func NewScanner(
-	k8sClient *k8s.Client,
+	clusters []*k8s.Client,
 	grpcClient *grpc.ReflectionClient,
 	bsrClient bsr.Client,
-	store *store.Store,
+	store store.Store,
 	cfg config.Config,
 ) *Scanner {
 	return &Scanner{
-		k8sClient:     k8sClient,
-		grpcClient:    grpcClient,
-		bsrClient:     bsrClient,
-		store:         store,
-		configMapNS:   cfg.ConfigMapNamespace,
-		configMapName: cfg.ConfigMapName,
-		bsrTemplate:   cfg.BSRTemplate,
-		scanInterval:  cfg.ScanInterval,
+		clusters:       clusters,
+		grpcClient:     grpcClient,
+		bsrClient:      bsrClient,
+		store:          store,
+		configMapNS:    cfg.ConfigMapNamespace,
+		configMapName:  cfg.ConfigMapName,
+		bsrTemplate:    cfg.BSRTemplate,
+		scanInterval:   cfg.ScanInterval,
+		portForward:    cfg.PortForward,
+		discoveryMode:  cfg.DiscoveryMode,
+		recordEvents:   cfg.RecordEvents,
+		replica:        ha.Identity(cfg.PodName, cfg.AdvertiseAddr),
+		shardBy:        cfg.ShardBy,
+		validated:      newValidationCache(),
+		requests:       make(chan string, maxQueuedScans),
+		bindingChanges: make(chan bindingChange, maxQueuedBindingChanges),
 	}
 }
 
-// Start begins the continuous scanning loop
+// Start begins the continuous scanning loop. On-demand scans queued with
+// RequestScan and created or updated SchemaBindings are handled on the same
+// goroutine, between regular cycles.
 func (s *Scanner) Start(ctx context.Context) error {
 	log.Printf("Starting scanner with interval: %s", s.scanInterval)
 
+	s.watchBindings(ctx)
+
 	ticker := time.NewTicker(s.scanInterval)
 	defer ticker.Stop()
 
@@ -98,13 +159,18 @@ func (s *Scanner) Start(ctx context.Context) error {
 			if err := s.runScan(ctx); err != nil {
 				log.Printf("Scan failed: %v", err)
 			}
+		case id := <-s.requests:
+			s.runScanJob(ctx, id)
+		case change := <-s.bindingChanges:
+			s.reconcileBindingChange(ctx, change)
 		}
 	}
 }
 
-// runScan performs a single scan cycle
+// runScan performs a single scan cycle across all configured clusters
 func (s *Scanner) runScan(ctx context.Context) error {
 	log.Println("Starting scan cycle...")
+	start := time.Now()
 
 	// Load service mappings from ConfigMap
 	mappings, err := s.loadServiceMappings(ctx)
@@ -113,113 +179,284 @@ func (s *Scanner) runScan(ctx context.Context) error {
 		mappings = domain.NewServiceMappings(nil) // Empty mappings
 	}
 
+	s.bsrCommits = make(map[string]string)
+	defer s.validated.endCycle()
+
+	// Pods mapped in an unreadable ConfigMap aren't discovered, so results are
+	// only pruned in cycles that could read it (or found it missing)
+	if err == nil || apierrors.IsNotFound(err) {
+		s.seen = make(map[string]bool)
+		defer func() { s.seen = nil }()
+	}
+
+	s.beginProgress()
+	defer s.endProgress()
+
+	var scanErrs []error
+	for _, cluster := range s.clusters {
+		if err := s.scanCluster(ctx, cluster, mappings); err != nil {
+			log.Printf("Scan of cluster %s failed: %v", cluster.Name(), err)
+			scanErrs = append(scanErrs, fmt.Errorf("cluster %s: %w", cluster.Name(), err))
+		}
+		s.clusterDone()
+	}
+	s.reevaluateAlerts()
+
+	log.Printf("Scan cycle completed. Results stored: %d", s.store.Count())
+	err = errors.Join(scanErrs...)
+	s.observeScan(start, err)
+	return err
+}
+
+// scanCluster discovers and validates the gRPC pods of a single cluster
+func (s *Scanner) scanCluster(ctx context.Context, cluster *k8s.Client, mappings domain.ServiceMappings) error {
+	pods, err := s.discoverPods(ctx, cluster, mappings)
+	if err != nil {
+		return err
+	}
+
+	// SchemaBindings select pods of their own and take precedence over the ConfigMap
+	bindings, bindingPods, bindingsErr := s.discoverBindingPods(ctx, cluster)
+	if bindingsErr != nil {
+		log.Printf("Warning: %v", bindingsErr)
+	}
+	pods = mergeBindingPods(pods, bindingPods)
+
+	log.Printf("Discovered %d gRPC pods in cluster %s", len(pods), cluster.Name())
+	if s.shard != nil {
+		pods = s.ownedPods(cluster.Name(), pods)
+		log.Printf("Scanning %d pods assigned to this replica", len(pods))
+	}
+	s.clusterDiscovered(cluster.Name(), len(pods))
+
+	// Validate each pod
+	bindingResults := make(map[string][]*domain.ScanResult)
+	for _, pod := range pods {
+		if pod.Overrides.Skip {
+			log.Printf("Skipping %s/%s/%s (%s annotation)", cluster.Name(), pod.Namespace, pod.Name, k8s.AnnotationSkip)
+			s.store.Delete(domain.ResultKey{
+				ClusterName: cluster.Name(),
+				Namespace:   pod.Namespace,
+				PodName:     pod.Name,
+				KubeService: pod.KubeService,
+			})
+			s.podScanned()
+			continue
+		}
+		result := s.validatePod(ctx, cluster, pod, mappings, false)
+		s.podScanned()
+		if pod.Binding != "" {
+			bindingResults[pod.Binding] = append(bindingResults[pod.Binding], result)
+		}
+	}
+
+	// Results of pods that were not found are stale, unless their SchemaBinding
+	// couldn't be listed
+	if bindingsErr == nil {
+		s.pruneResults(cluster.Name())
+	}
+
+	// Sharded replicas only saw part of each binding's pods in this cycle
+	if s.shard != nil {
+		bindingResults = s.storedBindingResults(cluster.Name())
+	}
+	s.reconcileBindings(ctx, cluster, bindings, bindingResults)
+	return nil
+}
+
+// discoverPods finds the pods to validate in a cluster using the configured discovery mode
+func (s *Scanner) discoverPods(ctx context.Context, cluster *k8s.Client, mappings domain.ServiceMappings) ([]k8s.PodInfo, error) {
 	// Get service names from ConfigMap for targeted discovery
 	serviceNames := mappings.GetServiceNames()
 
-	var pods []k8s.PodInfo
+	if s.discoveryMode == config.DiscoveryModeServices {
+		log.Printf("Using Service-based discovery in cluster %s", cluster.Name())
+		pods, err := cluster.DiscoverServiceEndpoints(ctx, serviceNames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover service endpoints: %w", err)
+		}
+		return pods, nil
+	}
+
 	if len(serviceNames) > 0 {
 		// Use ConfigMap-based discovery for better efficiency
-		log.Printf("Using ConfigMap-based discovery for %d services", len(serviceNames))
-		pods, err = s.k8sClient.DiscoverPodsForServices(ctx, serviceNames)
+		log.Printf("Using ConfigMap-based discovery for %d services in cluster %s", len(serviceNames), cluster.Name())
+		pods, err := cluster.DiscoverPodsForServices(ctx, serviceNames)
 		if err != nil {
-			return fmt.Errorf("failed to discover pods for services: %w", err)
+			return nil, fmt.Errorf("failed to discover pods for services: %w", err)
 		}
-	} else {
-		// Fallback to label-based discovery if ConfigMap is empty
-		log.Println("ConfigMap is empty, falling back to label-based discovery")
-		pods, err = s.k8sClient.DiscoverGRPCPods(ctx)
+
+		// Opted-in pods that declare their own module don't need a ConfigMap entry
+		annotated, err := cluster.DiscoverGRPCPods(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to discover pods: %w", err)
+			return nil, fmt.Errorf("failed to discover annotated pods: %w", err)
 		}
+		return appendAnnotatedPods(pods, annotated), nil
 	}
 
-	log.Printf("Discovered %d gRPC pods", len(pods))
+	// Fallback to label-based discovery if ConfigMap is empty
+	log.Printf("ConfigMap is empty, falling back to label-based discovery in cluster %s", cluster.Name())
+	pods, err := cluster.DiscoverGRPCPods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover pods: %w", err)
+	}
+	return pods, nil
+}
 
-	// Validate each pod
+// appendAnnotatedPods adds pods carrying a module annotation that are not already in the list
+func appendAnnotatedPods(pods, candidates []k8s.PodInfo) []k8s.PodInfo {
+	seen := make(map[string]bool, len(pods))
 	for _, pod := range pods {
-		s.validatePod(ctx, pod, mappings)
+		seen[pod.Namespace+"/"+pod.Name] = true
 	}
-
-	log.Printf("Scan cycle completed. Results stored: %d", s.store.Count())
-	return nil
+	for _, pod := range candidates {
+		if pod.Overrides.Module != "" && !seen[pod.Namespace+"/"+pod.Name] {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
 }
 
-// loadServiceMappings loads the ConfigMap or returns empty mappings on error
+// loadServiceMappings loads the ConfigMap from the home cluster or returns empty mappings on error
 func (s *Scanner) loadServiceMappings(ctx context.Context) (domain.ServiceMappings, error) {
-	return s.k8sClient.LoadServiceMappings(ctx, s.configMapNS, s.configMapName)
+	return s.clusters[0].LoadServiceMappings(ctx, s.configMapNS, s.configMapName)
 }
 
 // validatePod validates a single pod's schema against BSR.
 // It orchestrates the validation workflow: creating result, resolving BSR module,
-// fetching schemas, comparing them, and storing the result.
-func (s *Scanner) validatePod(ctx context.Context, pod k8s.PodInfo, mappings domain.ServiceMappings) {
-	result := s.createScanResult(pod)
+// fetching schemas, comparing them, and storing the result, which is also returned.
+// A fresh validation probes the pod even if its build was already validated.
+func (s *Scanner) validatePod(ctx context.Context, cluster *k8s.Client, pod k8s.PodInfo, mappings domain.ServiceMappings, fresh bool) *domain.ScanResult {
+	result := s.createScanResult(cluster.Name(), pod)
 
 	// Resolve BSR module
-	bsrModule := s.resolveBSRModule(pod.ServiceName, mappings)
+	bsrModule := s.resolveBSRModule(pod, mappings)
 	result.BSRModule = bsrModule
 
 	if bsrModule == "" {
 		result.Message = "No BSR module mapping found"
-		s.store.Set(result)
-		return
+		s.storeResult(cluster, pod, result)
+		return result
 	}
 
 	// Validate pod IP is not empty
 	if pod.IP == "" {
 		result.Message = "Pod IP is empty, cannot connect to gRPC service"
 		result.Status = domain.StatusUnknown
-		s.store.Set(result)
-		return
+		s.storeResult(cluster, pod, result)
+		return result
+	}
+
+	// Skip probing builds already validated against the same BSR commit
+	result.BSRCommit = s.resolveBSRCommit(ctx, bsrModule)
+	key := cacheKey(pod, bsrModule, result.BSRCommit)
+	if cached, ok := s.validated.lookup(key); ok && !fresh {
+		applyCachedOutcome(result, cached)
+		s.storeResult(cluster, pod, result)
+		log.Printf("Reused validation of %s for %s/%s/%s: %s", pod.ImageDigest, cluster.Name(), pod.Namespace, pod.Name, result.Status)
+		return result
 	}
 
 	// Fetch and compare schemas
-	s.fetchAndCompareSchemas(ctx, pod, bsrModule, result)
+	s.fetchAndCompareSchemas(ctx, cluster, pod, bsrModule, result)
+	s.validated.remember(key, result)
 
-	s.store.Set(result)
-	log.Printf("Validated %s/%s: %s", pod.Namespace, pod.Name, result.Status)
+	s.storeResult(cluster, pod, result)
+	log.Printf("Validated %s/%s/%s: %s", cluster.Name(), pod.Namespace, pod.Name, result.Status)
+	return result
 }
 
 // createScanResult initializes a new ScanResult from pod information.
 // All results start with StatusUnknown until validation completes.
-func (s *Scanner) createScanResult(pod k8s.PodInfo) *domain.ScanResult {
+func (s *Scanner) createScanResult(clusterName string, pod k8s.PodInfo) *domain.ScanResult {
 	return &domain.ScanResult{
+		ClusterName:  clusterName,
 		PodName:      pod.Name,
 		PodNamespace: pod.Namespace,
 		ServiceName:  pod.ServiceName,
 		PodIP:        pod.IP,
 		GRPCPort:     pod.GRPCPort,
+		PortSource:   pod.PortSource,
+		KubeService:  pod.KubeService,
+		Binding:      pod.Binding,
+		Owner:        pod.Overrides.Owner,
+		Workload:     pod.Workload,
+		Image:        pod.Image,
+		ImageDigest:  pod.ImageDigest,
 		LastChecked:  time.Now(),
 		Status:       domain.StatusUnknown,
+		ScannedBy:    s.replica,
 	}
 }
 
 // fetchAndCompareSchemas retrieves schemas from both the live pod and BSR,
 // then compares them to detect drift. Updates the result with comparison outcome.
-func (s *Scanner) fetchAndCompareSchemas(ctx context.Context, pod k8s.PodInfo, bsrModule string, result *domain.ScanResult) {
+func (s *Scanner) fetchAndCompareSchemas(ctx context.Context, cluster *k8s.Client, pod k8s.PodInfo, bsrModule string, result *domain.ScanResult) {
+	probeOpts := grpc.ProbeOptions{
+		TLS:                pod.Overrides.TLS,
+		InsecureSkipVerify: pod.Overrides.TLSInsecure,
+		ServerName:         pod.Overrides.TLSServerName,
+	}
+
+	// Without a detection rule for the port, probe the candidates for reflection
+	if pod.PortSource == domain.PortSourceDefault && len(pod.CandidatePorts) > 1 {
+		if port, ok := s.probeCandidatePorts(ctx, cluster, pod, probeOpts); ok {
+			result.GRPCPort = port
+			result.PortSource = domain.PortSourceProbe
+		}
+	}
+
 	// Fetch live schema via gRPC reflection
-	address := fmt.Sprintf("%s:%d", pod.IP, pod.GRPCPort)
-	log.Printf("Connecting to %s/%s at %s (port %d)", pod.Namespace, pod.Name, address, pod.GRPCPort)
-	liveSchema, err := s.grpcClient.FetchSchema(ctx, address)
+	probeStart := time.Now()
+	address, closeConn, err := s.podAddress(ctx, cluster, pod, result.GRPCPort)
 	if err != nil {
+		s.observeProbe(probeStart, reasonPortForward)
+		result.Message = fmt.Sprintf("Failed to port-forward to pod: %v", err)
+		result.Status = domain.StatusUnknown
+		return
+	}
+	defer closeConn()
+	log.Printf("Connecting to %s/%s at %s (port %d, %s)", pod.Namespace, pod.Name, address, result.GRPCPort, result.PortSource)
+	liveSchema, err := s.grpcClient.FetchSchema(ctx, address, probeOpts)
+	if err != nil {
+		s.observeProbe(probeStart, probeFailureReason(err))
 		result.Message = fmt.Sprintf("Failed to fetch live schema: %v", err)
 		result.Status = domain.StatusUnknown
 		return
 	}
+	s.observeProbe(probeStart, "")
+	result.LiveFingerprint = liveSchema.Fingerprint()
+	result.LiveSnapshot = s.storeSnapshot(liveSchema)
+
+	s.compareWithBSR(ctx, pod, bsrModule, liveSchema, result)
+}
 
+// compareWithBSR fetches the BSR schema of a module and compares the live
+// schema of a pod against it. Updates the result with comparison outcome.
+func (s *Scanner) compareWithBSR(ctx context.Context, pod k8s.PodInfo, bsrModule string, liveSchema *domain.SchemaDescriptor, result *domain.ScanResult) {
 	// Fetch truth schema from BSR
 	log.Printf("Fetching BSR schema for module: %s", bsrModule)
+	fetchStart := time.Now()
 	truthSchema, err := s.bsrClient.FetchSchema(ctx, bsrModule)
 	if err != nil {
+		s.observeBSRFetch(fetchStart, bsrFailureReason(err))
 		result.Message = fmt.Sprintf("Failed to fetch BSR schema: %v", err)
 		result.Status = domain.StatusUnknown
 		log.Printf("BSR fetch error for %s: %v", bsrModule, err)
 		return
 	}
+	s.observeBSRFetch(fetchStart, "")
 	log.Printf("BSR schema fetched: %d services, %d messages", len(truthSchema.Services), len(truthSchema.Messages))
+	result.BSRSnapshot = s.storeSnapshot(truthSchema)
+
+	// Leave out services the pod asked to ignore
+	if len(pod.Overrides.IgnoreServices) > 0 {
+		liveSchema = filterIgnoredServices(liveSchema, pod.Overrides.IgnoreServices)
+		truthSchema = filterIgnoredServices(truthSchema, pod.Overrides.IgnoreServices)
+	}
 
 	// Compare schemas and get detailed diff
-	match, diff := s.compareSchemas(liveSchema, truthSchema)
+	match, diff := s.compareSchemas(liveSchema, truthSchema, pod.StrictComparison)
 	result.SchemaDiff = diff
 
 	if match {
@@ -229,29 +466,133 @@ func (s *Scanner) fetchAndCompareSchemas(ctx context.Context, pod k8s.PodInfo, b
 	} else {
 		result.Status = domain.StatusMismatch
 		result.Message = s.buildDiffMessage(diff)
+		if len(diff.MethodMismatches) == 0 {
+			result.Message = buildServiceSetMessage(diff)
+		}
 		log.Printf("✗ Schema mismatch for %s/%s: %s", pod.Namespace, pod.Name, result.Message)
 	}
 }
 
-// resolveBSRModule determines the BSR module for a service
-func (s *Scanner) resolveBSRModule(serviceName string, mappings domain.ServiceMappings) string {
-	// Check ConfigMap first
-	if module, exists := mappings.Get(serviceName); exists {
+// storeSnapshot keeps the FileDescriptorSet of a schema in the store and
+// returns its snapshot ID ("" when the schema was not serialized)
+func (s *Scanner) storeSnapshot(schema *domain.SchemaDescriptor) string {
+	id := domain.SnapshotID(schema.FileDescriptorSet)
+	if id != "" {
+		s.store.PutSnapshot(id, schema.FileDescriptorSet)
+	}
+	return id
+}
+
+// podAddress returns the address for reaching a pod port, either the pod IP or a
+// local port-forward. The returned close function releases the port-forward.
+func (s *Scanner) podAddress(ctx context.Context, cluster *k8s.Client, pod k8s.PodInfo, port int32) (string, func(), error) {
+	if !s.portForward {
+		return fmt.Sprintf("%s:%d", pod.IP, port), func() {}, nil
+	}
+
+	pf, err := cluster.PortForward(ctx, pod.Namespace, pod.Name, port)
+	if err != nil {
+		return "", nil, err
+	}
+	return pf.LocalAddress, pf.Close, nil
+}
+
+// probeCandidatePorts tries each candidate port in order and returns the first
+// one that answers gRPC reflection
+func (s *Scanner) probeCandidatePorts(ctx context.Context, cluster *k8s.Client, pod k8s.PodInfo, opts grpc.ProbeOptions) (int32, bool) {
+	for _, port := range pod.CandidatePorts {
+		address, closeConn, err := s.podAddress(ctx, cluster, pod, port)
+		if err != nil {
+			continue
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		err = s.grpcClient.Probe(probeCtx, address, opts)
+		cancel()
+		closeConn()
+
+		if err == nil {
+			log.Printf("Probed reflection endpoint for %s/%s on port %d", pod.Namespace, pod.Name, port)
+			return port, true
+		}
+	}
+	return 0, false
+}
+
+// resolveBSRCommit resolves the BSR commit of a module once per scan cycle.
+// It returns "" when the BSR client can't resolve commits or resolution fails,
+// which disables the validation cache for the module.
+func (s *Scanner) resolveBSRCommit(ctx context.Context, module string) string {
+	resolver, ok := s.bsrClient.(bsr.CommitResolver)
+	if !ok {
+		return ""
+	}
+	if commit, ok := s.bsrCommits[module]; ok {
+		return commit
+	}
+
+	commit, err := resolver.ResolveCommit(ctx, module)
+	if err != nil {
+		log.Printf("Warning: Failed to resolve BSR commit for %s: %v", module, err)
+	}
+	s.bsrCommits[module] = commit
+	return commit
+}
+
+// resolveBSRModule determines the BSR module for a pod
+func (s *Scanner) resolveBSRModule(pod k8s.PodInfo, mappings domain.ServiceMappings) string {
+	// A module annotation on the pod takes precedence
+	if pod.Overrides.Module != "" {
+		return pod.Overrides.Module
+	}
+
+	// Check ConfigMap next
+	if module, exists := mappings.Get(pod.ServiceName); exists {
 		return module
 	}
 
 	// Fallback to template
 	if s.bsrTemplate != "" {
-		return strings.ReplaceAll(s.bsrTemplate, "{service}", serviceName)
+		return strings.ReplaceAll(s.bsrTemplate, "{service}", pod.ServiceName)
 	}
 
 	return ""
 }
 
+// filterIgnoredServices returns a copy of the schema without the ignored services.
+// Patterns ending in "*" match service names by prefix.
+func filterIgnoredServices(schema *domain.SchemaDescriptor, ignored []string) *domain.SchemaDescriptor {
+	filtered := &domain.SchemaDescriptor{
+		Services: make([]domain.ServiceDescriptor, 0, len(schema.Services)),
+		Messages: schema.Messages,
+	}
+	for _, svc := range schema.Services {
+		if !serviceIgnored(svc.Name, ignored) {
+			filtered.Services = append(filtered.Services, svc)
+		}
+	}
+	return filtered
+}
+
+// serviceIgnored reports whether a service name matches any ignore pattern
+func serviceIgnored(serviceName string, ignored []string) bool {
+	for _, pattern := range ignored {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(serviceName, prefix) {
+				return true
+			}
+		} else if serviceName == pattern {
+			return true
+		}
+	}
+	return false
+}
+
 // compareSchemas compares two schema descriptors and returns match status with detailed diff.
 // Only compares services that exist in BOTH live and BSR (intersection).
-// Services that exist only in live or only in BSR are tracked but don't affect sync status.
-func (s *Scanner) compareSchemas(live, truth *domain.SchemaDescriptor) (bool, *domain.SchemaDiff) {
+// Services that exist only in live or only in BSR are tracked but don't affect sync status,
+// unless strict is set (SchemaBinding "strict" comparison mode).
+func (s *Scanner) compareSchemas(live, truth *domain.SchemaDescriptor, strict bool) (bool, *domain.SchemaDiff) {
 	diff := &domain.SchemaDiff{
 		LiveServices:     []string{},
 		BSRServices:      []string{},
@@ -324,6 +665,10 @@ func (s *Scanner) compareSchemas(live, truth *domain.SchemaDescriptor) (bool, *d
 		}
 	}
 
+	if strict && (len(diff.MissingInLive) > 0 || len(diff.ExtraInLive) > 0) {
+		match = false
+	}
+
 	return match, diff
 }
 
@@ -424,3 +769,15 @@ func (s *Scanner) buildDiffMessage(diff *domain.SchemaDiff) string {
 	// No common services to compare
 	return "No common services to compare"
 }
+
+// buildServiceSetMessage describes a service set difference (strict comparison mode)
+func buildServiceSetMessage(diff *domain.SchemaDiff) string {
+	var parts []string
+	if len(diff.MissingInLive) > 0 {
+		parts = append(parts, "missing services: "+strings.Join(diff.MissingInLive, ","))
+	}
+	if len(diff.ExtraInLive) > 0 {
+		parts = append(parts, "extra services: "+strings.Join(diff.ExtraInLive, ","))
+	}
+	return "Service set differs: " + strings.Join(parts, "; ")
+}
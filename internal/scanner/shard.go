@@ -0,0 +1,74 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/config"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// Shard decides which pods this replica scans when scanning is split across replicas
+type Shard interface {
+	// Owns reports whether this replica is responsible for a key
+	Owns(key string) bool
+}
+
+// SetShard limits scanning to the pods the shard assigns to this replica.
+// It must be called before Start.
+func (s *Scanner) SetShard(shard Shard) {
+	s.shard = shard
+}
+
+// ownedPods filters pods down to those assigned to this replica
+func (s *Scanner) ownedPods(clusterName string, pods []k8s.PodInfo) []k8s.PodInfo {
+	if s.shard == nil {
+		return pods
+	}
+
+	owned := make([]k8s.PodInfo, 0, len(pods))
+	for _, pod := range pods {
+		if s.shard.Owns(s.shardKey(clusterName, pod)) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned
+}
+
+// shardKey returns the key a pod is assigned by: the pod itself, or its
+// service so that all replicas of a service are scanned together
+func (s *Scanner) shardKey(clusterName string, pod k8s.PodInfo) string {
+	if s.shardBy == config.ShardByService {
+		return clusterName + "/" + pod.Namespace + "/" + pod.ServiceName
+	}
+	return clusterName + "/" + pod.Namespace + "/" + pod.Name
+}
+
+// ownsBinding reports whether this replica writes the status of a SchemaBinding
+func (s *Scanner) ownsBinding(clusterName string, binding k8s.SchemaBinding) bool {
+	return s.shard == nil || s.shard.Owns("binding:"+clusterName+"/"+binding.Key())
+}
+
+// storedBindingResults collects the stored results of a cluster's bound pods,
+// including those merged from other replicas
+func (s *Scanner) storedBindingResults(clusterName string) map[string][]*domain.ScanResult {
+	results := make(map[string][]*domain.ScanResult)
+	for _, result := range s.store.GetAll() {
+		if result.ClusterName == clusterName && result.Binding != "" {
+			results[result.Binding] = append(results[result.Binding], result)
+		}
+	}
+	return results
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+// validationCache remembers comparison outcomes per (image digest, BSR module,
+// BSR commit, probe settings) so pods running an already validated build
+// against an unchanged BSR commit are not probed again. Entries not used during
+// a scan cycle are evicted when the cycle ends.
+//
+// The cache needs the BSR commit, so it is only active when the BSR client
+// implements bsr.CommitResolver (BufClient does, the deprecated HTTPClient
+// does not); otherwise every pod is probed on every cycle.
+//
+// The cache is only accessed from the scanning goroutine and is not locked.
+type validationCache struct {
+	entries map[string]*domain.ScanResult
+	used    map[string]bool
+}
+
+// newValidationCache creates an empty validation cache
+func newValidationCache() *validationCache {
+	return &validationCache{
+		entries: make(map[string]*domain.ScanResult),
+		used:    make(map[string]bool),
+	}
+}
+
+// cacheKey builds the cache key for a pod, or "" when the pod can't be cached
+// because its image digest or the BSR commit is unknown. The key includes the
+// probe port and TLS settings, since the same build reached on another port or
+// with other TLS settings can expose a different schema or none at all.
+func cacheKey(pod k8s.PodInfo, bsrModule, bsrCommit string) string {
+	if pod.ImageDigest == "" || bsrCommit == "" {
+		return ""
+	}
+	candidates := make([]string, 0, len(pod.CandidatePorts))
+	for _, port := range pod.CandidatePorts {
+		candidates = append(candidates, strconv.Itoa(int(port)))
+	}
+	return strings.Join([]string{
+		pod.ImageDigest,
+		bsrModule,
+		bsrCommit,
+		strings.Join(pod.Overrides.IgnoreServices, ","),
+		strconv.FormatBool(pod.StrictComparison),
+		strconv.Itoa(int(pod.GRPCPort)),
+		strings.Join(candidates, ","),
+		strconv.FormatBool(pod.Overrides.TLS),
+		strconv.FormatBool(pod.Overrides.TLSInsecure),
+		pod.Overrides.TLSServerName,
+	}, "|")
+}
+
+// lookup returns the cached outcome for the key and marks it as used
+func (vc *validationCache) lookup(key string) (*domain.ScanResult, bool) {
+	if key == "" {
+		return nil, false
+	}
+	cached, ok := vc.entries[key]
+	if ok {
+		vc.used[key] = true
+	}
+	return cached, ok
+}
+
+// remember stores a definitive (SYNC or MISMATCH) outcome for the key
+func (vc *validationCache) remember(key string, result *domain.ScanResult) {
+	if key == "" || result.Status == domain.StatusUnknown {
+		return
+	}
+	vc.entries[key] = result
+	vc.used[key] = true
+}
+
+// endCycle evicts entries that were not used during the finished scan cycle
+func (vc *validationCache) endCycle() {
+	for key := range vc.entries {
+		if !vc.used[key] {
+			delete(vc.entries, key)
+		}
+	}
+	vc.used = make(map[string]bool)
+}
+
+// applyCachedOutcome copies the comparison outcome of a cached result onto a
+// new result, including the port it was probed on: with several candidate
+// ports, that is the port found serving reflection, not the pod's default.
+func applyCachedOutcome(result, cached *domain.ScanResult) {
+	result.GRPCPort = cached.GRPCPort
+	result.PortSource = cached.PortSource
+	result.Status = cached.Status
+	result.Message = cached.Message
+	result.SchemaDiff = cached.SchemaDiff
+	result.LiveFingerprint = cached.LiveFingerprint
+	result.LiveSnapshot = cached.LiveSnapshot
+	result.BSRSnapshot = cached.BSRSnapshot
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 ProtoDiff Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/uzdada/protodiff/internal/adapters/k8s"
+	"github.com/uzdada/protodiff/internal/core/domain"
+)
+
+func TestCacheKeyIncludesProbeSettings(t *testing.T) {
+	base := k8s.PodInfo{ImageDigest: "sha256:abc", GRPCPort: 9090}
+	key := cacheKey(base, "buf.build/acme/users", "c1")
+	if key == "" {
+		t.Fatal("cacheKey() = \"\", want a key for a pod with an image digest")
+	}
+
+	variants := map[string]func(*k8s.PodInfo){
+		"port":            func(p *k8s.PodInfo) { p.GRPCPort = 9091 },
+		"candidate ports": func(p *k8s.PodInfo) { p.CandidatePorts = []int32{9090, 50051} },
+		"tls":             func(p *k8s.PodInfo) { p.Overrides.TLS = true },
+		"tls insecure":    func(p *k8s.PodInfo) { p.Overrides.TLS, p.Overrides.TLSInsecure = true, true },
+		"tls server name": func(p *k8s.PodInfo) { p.Overrides.TLSServerName = "users.internal" },
+	}
+	for name, change := range variants {
+		pod := base
+		change(&pod)
+		if got := cacheKey(pod, "buf.build/acme/users", "c1"); got == key {
+			t.Errorf("cacheKey() with a different %s = %q, want a different key", name, got)
+		}
+	}
+
+	if got := cacheKey(base, "buf.build/acme/users", ""); got != "" {
+		t.Errorf("cacheKey() without a BSR commit = %q, want \"\"", got)
+	}
+}
+
+func TestApplyCachedOutcome(t *testing.T) {
+	cache := newValidationCache()
+	pod := k8s.PodInfo{
+		ImageDigest:    "sha256:abc",
+		GRPCPort:       9090,
+		PortSource:     domain.PortSourceDefault,
+		CandidatePorts: []int32{9090, 50051},
+	}
+	key := cacheKey(pod, "buf.build/acme/users", "c1")
+
+	// The first pod was found serving reflection on a candidate port
+	cache.remember(key, &domain.ScanResult{
+		PodName:         "users-a",
+		GRPCPort:        50051,
+		PortSource:      domain.PortSourceProbe,
+		Status:          domain.StatusMismatch,
+		Message:         "schema drift",
+		LiveFingerprint: "live-1",
+	})
+
+	cached, ok := cache.lookup(key)
+	if !ok {
+		t.Fatal("lookup() found no outcome for a remembered key")
+	}
+	result := &domain.ScanResult{PodName: "users-b", GRPCPort: pod.GRPCPort, PortSource: pod.PortSource, Status: domain.StatusUnknown}
+	applyCachedOutcome(result, cached)
+
+	if result.GRPCPort != 50051 || result.PortSource != domain.PortSourceProbe {
+		t.Errorf("port = %d (%s), want 50051 (%s)", result.GRPCPort, result.PortSource, domain.PortSourceProbe)
+	}
+	if result.Status != domain.StatusMismatch || result.Message != "schema drift" || result.LiveFingerprint != "live-1" {
+		t.Errorf("outcome = %s %q %q, want the cached outcome", result.Status, result.Message, result.LiveFingerprint)
+	}
+	if result.PodName != "users-b" {
+		t.Errorf("PodName = %q, want the new pod's name", result.PodName)
+	}
+}